@@ -14,15 +14,6 @@ import (
 	"github.com/zercle/zercle-go-template/internal/config"
 )
 
-var (
-	// Version is set at build time via -ldflags "-X main.Version=...".
-	Version = "dev"
-	// CommitSHA is set at build time via -ldflags "-X main.CommitSHA=...".
-	CommitSHA = "unknown"
-	// BuildTime is set at build time via -ldflags "-X main.BuildTime=...".
-	BuildTime = "unknown"
-)
-
 func main() {
 	os.Exit(run())
 }
@@ -41,10 +32,6 @@ func run() (exitCode int) {
 		return 1
 	}
 
-	app.Version = Version
-	app.CommitSHA = CommitSHA
-	app.BuildTime = BuildTime
-
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
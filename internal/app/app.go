@@ -14,26 +14,22 @@ import (
 	"github.com/zercle/zercle-go-template/internal/config"
 	exampledi "github.com/zercle/zercle-go-template/internal/features/example/di"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/featureflags"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/messaging/valkey"
+	"github.com/zercle/zercle-go-template/internal/shared/buildinfo"
 	"github.com/zercle/zercle-go-template/internal/shared/server"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
-)
-
-// Version metadata is populated by cmd/server/main.go via these package-level
-// variables before Run is called.
-var (
-	Version   = "dev"
-	CommitSHA = "unknown"
-	BuildTime = "unknown"
+	"github.com/zercle/zercle-go-template/pkg/clock"
 )
 
 // Build wires the DI container in dependency order and returns the
 // orchestrated application along with the populated injector.
 //
-// The sequence is config → telemetry → database → valkey → shared servers →
-// example feature. On error the partially-wired injector is returned; the
-// caller is responsible for calling injector.Shutdown() to release any
-// providers that were successfully constructed.
+// The sequence is config and clock → telemetry → database → valkey → shared
+// servers → feature flags → example feature. On error the partially-wired
+// injector is returned; the caller is responsible for calling
+// injector.Shutdown() to release any providers that were successfully
+// constructed.
 func Build(ctx context.Context, cfg *config.Config) (*server.Application, do.Injector, error) {
 	if cfg == nil {
 		return nil, nil, fmt.Errorf("config is nil")
@@ -42,6 +38,7 @@ func Build(ctx context.Context, cfg *config.Config) (*server.Application, do.Inj
 	injector := do.New()
 
 	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, clock.New())
 
 	if err := telemetry.Register(ctx, injector); err != nil {
 		return nil, injector, fmt.Errorf("register telemetry: %w", err)
@@ -51,10 +48,11 @@ func Build(ctx context.Context, cfg *config.Config) (*server.Application, do.Inj
 	if err != nil {
 		return nil, injector, fmt.Errorf("resolve logger: %w", err)
 	}
+	build := buildinfo.Get(cfg.App.Name)
 	logger.Info().
-		Str("version", Version).
-		Str("commit", CommitSHA).
-		Str("build_time", BuildTime).
+		Str("version", build.Version).
+		Str("commit", build.Commit).
+		Str("build_time", build.BuildTime).
 		Str("env", cfg.App.Environment).
 		Msg("starting server")
 
@@ -65,15 +63,26 @@ func Build(ctx context.Context, cfg *config.Config) (*server.Application, do.Inj
 	if err := valkey.Register(ctx, injector); err != nil {
 		return nil, injector, fmt.Errorf("register valkey: %w", err)
 	}
+	if !cfg.Valkey.Enabled {
+		logger.Info().Msg("valkey disabled")
+	}
 
 	if err := server.Register(injector); err != nil {
 		return nil, injector, fmt.Errorf("register shared servers: %w", err)
 	}
 
+	if err := featureflags.Register(injector); err != nil {
+		return nil, injector, fmt.Errorf("register feature flags: %w", err)
+	}
+
 	if err := exampledi.Register(injector); err != nil {
 		return nil, injector, fmt.Errorf("register example feature: %w", err)
 	}
 
+	if err := db.VerifySchema(ctx, injector); err != nil {
+		return nil, injector, fmt.Errorf("verify schema: %w", err)
+	}
+
 	application := server.NewApplication(injector, cfg, logger)
 	return application, injector, nil
 }
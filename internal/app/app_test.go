@@ -35,25 +35,29 @@ func TestBuild_DatabaseUnreachable(t *testing.T) {
 			IdleTimeout:        60 * time.Second,
 			BodyLimit:          "1M",
 			HealthProbeTimeout: 5 * time.Second,
+			JSONMaxDepth:       32,
+			JSONMaxElements:    10000,
 		},
 		GRPC: config.GRPCConfig{Host: "0.0.0.0", Port: 50051},
 		DB: config.DBConfig{
-			Host:           "192.0.2.1", // TEST-NET-1, should not be reachable
-			Port:           5432,
-			Name:           "app",
-			User:           "postgres",
-			Password:       "postgres",
-			SSLMode:        "disable",
-			MaxConns:       2,
-			MaxIdleConns:   1,
-			MaxConnIdle:    5 * time.Second,
-			MaxConnLife:    10 * time.Second,
-			ConnectTimeout: 1 * time.Second,
+			Host:               "192.0.2.1", // TEST-NET-1, should not be reachable
+			Port:               5432,
+			Name:               "app",
+			User:               "postgres",
+			Password:           "postgres",
+			SSLMode:            "disable",
+			MaxConns:           2,
+			MaxIdleConns:       1,
+			MaxConnIdle:        5 * time.Second,
+			MaxConnLife:        10 * time.Second,
+			ConnectTimeout:     1 * time.Second,
+			ConnectMaxAttempts: 1,
 		},
 		Valkey: config.ValkeyConfig{
-			Host: "127.0.0.1",
-			Port: 6379,
-			DB:   0,
+			Enabled: true,
+			Host:    "127.0.0.1",
+			Port:    6379,
+			DB:      0,
 		},
 		OTel: config.OTelConfig{Exporter: "none", ServiceName: "test"},
 		Log:  config.LogConfig{Level: "info", Format: "json"},
@@ -61,6 +65,7 @@ func TestBuild_DatabaseUnreachable(t *testing.T) {
 			DefaultPageSize: 20,
 			MaxPageSize:     100,
 			MaxNameLength:   255,
+			MaxOffset:       10000,
 		},
 	}
 
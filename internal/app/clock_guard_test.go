@@ -0,0 +1,55 @@
+//go:build unit
+
+package app_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// wallClockCall matches direct reads of the system clock.
+var wallClockCall = regexp.MustCompile(`\btime\.(Now|Since|Until|NewTicker|NewTimer|After|Tick)\(`)
+
+// TestNoWallClockInUseCases fails when a feature's domain or service package
+// reads the system clock directly instead of taking a clock.Clock, which
+// keeps time-dependent use cases deterministic under test.
+func TestNoWallClockInUseCases(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join("..", "features")
+	var offenders []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		switch filepath.Base(filepath.Dir(path)) {
+		case "domain", "service":
+		default:
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for i, line := range bytes.Split(src, []byte("\n")) {
+			if wallClockCall.Match(line) {
+				offenders = append(offenders, fmt.Sprintf("%s:%d", path, i+1))
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, offenders, "use an injected clock.Clock instead of the time package's wall clock")
+}
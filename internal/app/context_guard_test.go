@@ -0,0 +1,54 @@
+//go:build unit
+
+package app_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNoBackgroundContextOutsideMain fails when library code starts from
+// context.Background or context.TODO instead of the caller's context, which
+// would detach work from request cancellation. Only cmd/ entry points own a
+// root context.
+func TestNoBackgroundContextOutsideMain(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join("..", "..")
+	var offenders []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "cmd", "test", "vendor", ".git":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for i, line := range bytes.Split(src, []byte("\n")) {
+			if bytes.Contains(line, []byte("context.Background()")) || bytes.Contains(line, []byte("context.TODO()")) {
+				offenders = append(offenders, fmt.Sprintf("%s:%d", path, i+1))
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, offenders, "pass the caller's context instead of context.Background/TODO")
+}
@@ -13,8 +13,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
+
+	"github.com/zercle/zercle-go-template/internal/shared/validation"
 )
 
 // leafBinding describes a configuration leaf that is explicitly bound to an
@@ -26,14 +27,16 @@ type leafBinding struct {
 
 // Config is the single source of truth for application configuration.
 type Config struct {
-	App     AppConfig     `mapstructure:"app" yaml:"app" validate:"required"`
-	HTTP    HTTPConfig    `mapstructure:"http" yaml:"http" validate:"required"`
-	GRPC    GRPCConfig    `mapstructure:"grpc" yaml:"grpc" validate:"required"`
-	DB      DBConfig      `mapstructure:"db" yaml:"db" validate:"required"`
-	Valkey  ValkeyConfig  `mapstructure:"valkey" yaml:"valkey" validate:"required"`
-	OTel    OTelConfig    `mapstructure:"otel" yaml:"otel" validate:"required"`
-	Log     LogConfig     `mapstructure:"log" yaml:"log" validate:"required"`
-	Example ExampleConfig `mapstructure:"example" yaml:"example"`
+	App          AppConfig          `mapstructure:"app" yaml:"app" validate:"required"`
+	HTTP         HTTPConfig         `mapstructure:"http" yaml:"http" validate:"required"`
+	Admin        AdminConfig        `mapstructure:"admin" yaml:"admin"`
+	GRPC         GRPCConfig         `mapstructure:"grpc" yaml:"grpc" validate:"required"`
+	DB           DBConfig           `mapstructure:"db" yaml:"db" validate:"required"`
+	Valkey       ValkeyConfig       `mapstructure:"valkey" yaml:"valkey" validate:"required"`
+	OTel         OTelConfig         `mapstructure:"otel" yaml:"otel" validate:"required"`
+	Log          LogConfig          `mapstructure:"log" yaml:"log" validate:"required"`
+	FeatureFlags FeatureFlagsConfig `mapstructure:"feature_flags" yaml:"feature_flags"`
+	Example      ExampleConfig      `mapstructure:"example" yaml:"example"`
 }
 
 // AppConfig holds process-level settings.
@@ -57,6 +60,45 @@ type HTTPConfig struct {
 	CORSAllowOrigins   []string      `mapstructure:"cors_allow_origins" yaml:"cors_allow_origins" env:"HTTP_CORS_ALLOW_ORIGINS"`
 	CORSAllowMethods   []string      `mapstructure:"cors_allow_methods" yaml:"cors_allow_methods" env:"HTTP_CORS_ALLOW_METHODS"`
 	CORSAllowHeaders   []string      `mapstructure:"cors_allow_headers" yaml:"cors_allow_headers" env:"HTTP_CORS_ALLOW_HEADERS"`
+	// EnableProfiling exposes net/http/pprof under /debug/pprof outside the
+	// development environment. Development always exposes it.
+	EnableProfiling bool `mapstructure:"enable_profiling" yaml:"enable_profiling" env:"HTTP_ENABLE_PROFILING"`
+	// StrictJSON rejects JSON request bodies containing unknown fields outside
+	// the development environment, where it is always on.
+	StrictJSON bool `mapstructure:"strict_json" yaml:"strict_json" env:"HTTP_STRICT_JSON"`
+	// JSONMaxDepth and JSONMaxElements bound the nesting depth and the
+	// per-array/object element count of JSON request bodies; larger bodies
+	// are rejected with 400 before they are decoded.
+	JSONMaxDepth    int `mapstructure:"json_max_depth" yaml:"json_max_depth" env:"HTTP_JSON_MAX_DEPTH" validate:"required,min=1"`
+	JSONMaxElements int `mapstructure:"json_max_elements" yaml:"json_max_elements" env:"HTTP_JSON_MAX_ELEMENTS" validate:"required,min=1"`
+	// Compression compresses response bodies of at least CompressionMinSize
+	// bytes with brotli or gzip, whichever the client prefers.
+	// CompressionLevel is a gzip level; 0 uses the library default. Brotli
+	// always uses its default quality.
+	CompressionEnabled bool `mapstructure:"compression_enabled" yaml:"compression_enabled" env:"HTTP_COMPRESSION_ENABLED"`
+	CompressionMinSize int  `mapstructure:"compression_min_size" yaml:"compression_min_size" env:"HTTP_COMPRESSION_MIN_SIZE" validate:"min=0"`
+	CompressionLevel   int  `mapstructure:"compression_level" yaml:"compression_level" env:"HTTP_COMPRESSION_LEVEL" validate:"min=-2,max=9"`
+	// AllowedHosts rejects requests whose Host header matches none of these
+	// names ("api.example.com" or "*.example.com") with 400. Empty allows
+	// every host. Health probes are never checked.
+	AllowedHosts []string `mapstructure:"allowed_hosts" yaml:"allowed_hosts" env:"HTTP_ALLOWED_HOSTS"`
+	// TrustedProxies lists the CIDR ranges of reverse proxies whose
+	// X-Forwarded-For entries are believed when resolving the client IP.
+	// Empty uses the connection's peer address and ignores the header.
+	TrustedProxies []string `mapstructure:"trusted_proxies" yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES" validate:"dive,cidr"`
+	// SlashRedirect answers GET and HEAD requests whose path has repeated or
+	// trailing slashes with a 308 to the canonical path. Other methods, and
+	// every method when it is false, are rewritten in place instead.
+	SlashRedirect bool `mapstructure:"slash_redirect" yaml:"slash_redirect" env:"HTTP_SLASH_REDIRECT"`
+}
+
+// AdminConfig holds the optional admin HTTP listener settings. When enabled,
+// /metrics, /debug/pprof, and /admin/* are served on this listener only and
+// are removed from the public one.
+type AdminConfig struct {
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled" env:"ADMIN_ENABLED"`
+	Host    string `mapstructure:"host" yaml:"host" env:"ADMIN_HOST" validate:"omitempty,ip|hostname"`
+	Port    int    `mapstructure:"port" yaml:"port" env:"ADMIN_PORT" validate:"omitempty,min=1,max=65535"`
 }
 
 // GRPCConfig holds the gRPC server settings.
@@ -81,12 +123,22 @@ type DBConfig struct {
 	MaxConnIdle    time.Duration `mapstructure:"max_conn_idle" yaml:"max_conn_idle" env:"DB_MAX_CONN_IDLE" validate:"required,min=1s"`
 	MaxConnLife    time.Duration `mapstructure:"max_conn_life" yaml:"max_conn_life" env:"DB_MAX_CONN_LIFE" validate:"required,min=1s"`
 	ConnectTimeout time.Duration `mapstructure:"connect_timeout" yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT" validate:"required,min=1s"`
+	// ConnectMaxAttempts bounds how many times the initial connection and ping
+	// are tried before startup fails. ConnectRetryDelay is the wait after the
+	// first failure; it doubles after each further failure.
+	ConnectMaxAttempts int           `mapstructure:"connect_max_attempts" yaml:"connect_max_attempts" env:"DB_CONNECT_MAX_ATTEMPTS" validate:"required,min=1"`
+	ConnectRetryDelay  time.Duration `mapstructure:"connect_retry_delay" yaml:"connect_retry_delay" env:"DB_CONNECT_RETRY_DELAY" validate:"min=0"`
+	// VerifySchema runs every feature's schema check at startup, failing
+	// fast on a missing table or column, and adds them to readiness.
+	VerifySchema bool `mapstructure:"verify_schema" yaml:"verify_schema" env:"DB_VERIFY_SCHEMA"`
 }
 
-// ValkeyConfig holds the Valkey client settings.
+// ValkeyConfig holds the Valkey client settings. When Enabled is false no
+// client is created and the service starts without Valkey.
 type ValkeyConfig struct {
-	Host           string        `mapstructure:"host" yaml:"host" env:"VALKEY_HOST" validate:"required,hostname|ip"`
-	Port           int           `mapstructure:"port" yaml:"port" env:"VALKEY_PORT" validate:"required,min=1,max=65535"`
+	Enabled        bool          `mapstructure:"enabled" yaml:"enabled" env:"VALKEY_ENABLED"`
+	Host           string        `mapstructure:"host" yaml:"host" env:"VALKEY_HOST" validate:"omitempty,hostname|ip"`
+	Port           int           `mapstructure:"port" yaml:"port" env:"VALKEY_PORT" validate:"omitempty,min=1,max=65535"`
 	Password       string        `mapstructure:"password" yaml:"password" env:"VALKEY_PASSWORD"`
 	DB             int           `mapstructure:"db" yaml:"db" env:"VALKEY_DB" validate:"min=0"`
 	ConnectTimeout time.Duration `mapstructure:"connect_timeout" yaml:"connect_timeout" env:"VALKEY_CONNECT_TIMEOUT" validate:"omitempty,min=1s"`
@@ -104,6 +156,26 @@ type OTelConfig struct {
 type LogConfig struct {
 	Level  string `mapstructure:"level" yaml:"level" env:"LOG_LEVEL" validate:"oneof=trace debug info warn error fatal panic"`
 	Format string `mapstructure:"format" yaml:"format" env:"LOG_FORMAT" validate:"oneof=json console"`
+	// IncludeCaller adds the file:line of each log call.
+	IncludeCaller bool `mapstructure:"include_caller" yaml:"include_caller" env:"LOG_INCLUDE_CALLER"`
+	// TimeFormat selects the timestamp encoding; empty means rfc3339.
+	TimeFormat string `mapstructure:"time_format" yaml:"time_format" env:"LOG_TIME_FORMAT" validate:"omitempty,oneof=rfc3339 rfc3339nano unix unixms unixmicro"`
+	// Sampling thins out repetitive lines such as the per-request access log:
+	// per key and window, the first SamplingFirst lines at SamplingLevel or
+	// below pass, then one in every SamplingThereafter. Errors are never
+	// sampled.
+	SamplingEnabled    bool          `mapstructure:"sampling_enabled" yaml:"sampling_enabled" env:"LOG_SAMPLING_ENABLED"`
+	SamplingLevel      string        `mapstructure:"sampling_level" yaml:"sampling_level" env:"LOG_SAMPLING_LEVEL" validate:"omitempty,oneof=trace debug info warn"`
+	SamplingWindow     time.Duration `mapstructure:"sampling_window" yaml:"sampling_window" env:"LOG_SAMPLING_WINDOW" validate:"omitempty,min=1ms"`
+	SamplingFirst      uint64        `mapstructure:"sampling_first" yaml:"sampling_first" env:"LOG_SAMPLING_FIRST"`
+	SamplingThereafter uint64        `mapstructure:"sampling_thereafter" yaml:"sampling_thereafter" env:"LOG_SAMPLING_THEREAFTER"`
+}
+
+// FeatureFlagsConfig holds the feature flag provider settings. Static lists
+// flag keys that are fully on whenever the feature_flags table cannot be read.
+type FeatureFlagsConfig struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl" yaml:"cache_ttl" env:"FEATURE_FLAGS_CACHE_TTL" validate:"omitempty,min=1s"`
+	Static   []string      `mapstructure:"static" yaml:"static" env:"FEATURE_FLAGS_STATIC"`
 }
 
 // ExampleConfig is a feature toggle and settings for the stub feature.
@@ -112,6 +184,22 @@ type ExampleConfig struct {
 	DefaultPageSize int32 `mapstructure:"default_page_size" yaml:"default_page_size" env:"EXAMPLE_DEFAULT_PAGE_SIZE"`
 	MaxPageSize     int32 `mapstructure:"max_page_size" yaml:"max_page_size" env:"EXAMPLE_MAX_PAGE_SIZE"`
 	MaxNameLength   int32 `mapstructure:"max_name_length" yaml:"max_name_length" env:"EXAMPLE_MAX_NAME_LENGTH"`
+	// MaxOffset is the deepest offset a listing may request; deeper pages
+	// are rejected with 400 instead of scanning and discarding that many rows.
+	MaxOffset int32 `mapstructure:"max_offset" yaml:"max_offset" env:"EXAMPLE_MAX_OFFSET"`
+	// DefaultSort orders listings that request no sort, in the same
+	// field[:asc|desc] syntax as the sort query parameter. id is always
+	// appended as a tie-breaker.
+	DefaultSort string `mapstructure:"default_sort" yaml:"default_sort" env:"EXAMPLE_DEFAULT_SORT"`
+	// ListCacheTTL is how long identical item listings are served from
+	// memory. Each replica keeps its own cache and only drops it on its own
+	// writes, so a listing may lag another replica's writes by up to
+	// ListCacheTTL plus ListCacheStale. Zero, the default, disables the
+	// cache.
+	ListCacheTTL time.Duration `mapstructure:"list_cache_ttl" yaml:"list_cache_ttl" env:"EXAMPLE_LIST_CACHE_TTL" validate:"min=0"`
+	// ListCacheStale is how long an expired listing keeps being served while
+	// one background load refreshes it.
+	ListCacheStale time.Duration `mapstructure:"list_cache_stale" yaml:"list_cache_stale" env:"EXAMPLE_LIST_CACHE_STALE" validate:"min=0"`
 }
 
 // exampleMaxPageSizeUpperBound caps EXAMPLE_MAX_PAGE_SIZE to a sane ceiling so
@@ -122,23 +210,40 @@ const exampleMaxPageSizeUpperBound int32 = 1000
 // unreasonable storage/validation costs per name.
 const exampleMaxNameLengthUpperBound int32 = 4096
 
-// validateExamplePositivity returns an error if any of the example config
-// fields are less than 1, enforcing a minimum value when the feature is enabled.
+// validateExamplePositivity returns an error for each example config field
+// that is less than 1, enforcing a minimum value when the feature is enabled.
 func validateExamplePositivity(cfg ExampleConfig) error {
+	var errs []error
 	if cfg.DefaultPageSize < 1 {
-		return fmt.Errorf("EXAMPLE_DEFAULT_PAGE_SIZE must be >= 1")
+		errs = append(errs, fmt.Errorf("EXAMPLE_DEFAULT_PAGE_SIZE must be >= 1"))
 	}
 	if cfg.MaxPageSize < 1 {
-		return fmt.Errorf("EXAMPLE_MAX_PAGE_SIZE must be >= 1")
+		errs = append(errs, fmt.Errorf("EXAMPLE_MAX_PAGE_SIZE must be >= 1"))
 	}
 	if cfg.MaxNameLength < 1 {
-		return fmt.Errorf("EXAMPLE_MAX_NAME_LENGTH must be >= 1")
+		errs = append(errs, fmt.Errorf("EXAMPLE_MAX_NAME_LENGTH must be >= 1"))
+	}
+	if cfg.MaxOffset < 1 {
+		errs = append(errs, fmt.Errorf("EXAMPLE_MAX_OFFSET must be >= 1"))
+	}
+	return errors.Join(errs...)
+}
+
+// insecureDBPassword is the sample password shipped in config.yaml and
+// .env.example; it must never reach production.
+const insecureDBPassword = "postgres"
+
+// validateProduction rejects settings that are only acceptable for local
+// development.
+func (c *Config) validateProduction() error {
+	if c.DB.Password == insecureDBPassword {
+		return fmt.Errorf("DB_PASSWORD must not be the sample default in production")
 	}
 	return nil
 }
 
 // validate is the package-level validator instance.
-var validate = validator.New()
+var validate = validation.Validator()
 
 // Load reads config.yaml (or CONFIG_FILE) and environment variables and returns
 // a typed configuration. Environment variables are unprefixed and use
@@ -179,49 +284,95 @@ func Load() (*Config, error) {
 	}
 
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(decodeHook())); err != nil {
 		return nil, fmt.Errorf("decode config: %w", err)
 	}
 
 	return &cfg, nil
 }
 
-// Validate runs go-playground/validator and cross-section checks.
+// Validate runs go-playground/validator and cross-section checks. It reports
+// every failure at once, joined with errors.Join, so operators can fix the
+// whole configuration in one pass.
 func (c *Config) Validate() error {
+	var errs []error
 	if err := validate.Struct(c); err != nil {
-		return fmt.Errorf("config validation failed: %w", err)
+		errs = append(errs, fmt.Errorf("config validation failed: %w", err))
 	}
 
-	if c.OTel.Exporter == "otlp" && c.OTel.Endpoint == "" {
-		return fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT is required when OTEL_EXPORTER=otlp")
+	if c.IsProduction() {
+		errs = append(errs, c.validateProduction())
 	}
 
 	if c.OTel.Exporter == "otlp" {
-		if _, err := url.Parse(c.OTel.Endpoint); err != nil {
-			return fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT is invalid: %w", err)
+		if c.OTel.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT is required when OTEL_EXPORTER=otlp"))
+		} else if _, err := url.Parse(c.OTel.Endpoint); err != nil {
+			errs = append(errs, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT is invalid: %w", err))
+		}
+	}
+
+	if c.Admin.Enabled {
+		if c.Admin.Port == 0 {
+			errs = append(errs, fmt.Errorf("ADMIN_PORT is required when ADMIN_ENABLED=true"))
+		} else if c.Admin.Port == c.HTTP.Port || c.Admin.Port == c.GRPC.Port {
+			errs = append(errs, fmt.Errorf("ADMIN_PORT must differ from HTTP_PORT and GRPC_PORT"))
+		}
+	}
+
+	if c.Valkey.Enabled {
+		if c.Valkey.Host == "" {
+			errs = append(errs, fmt.Errorf("VALKEY_HOST is required when VALKEY_ENABLED=true"))
+		}
+		if c.Valkey.Port == 0 {
+			errs = append(errs, fmt.Errorf("VALKEY_PORT is required when VALKEY_ENABLED=true"))
 		}
 	}
 
 	if c.DB.MaxConns < c.DB.MaxIdleConns {
-		return fmt.Errorf("DB_MAX_CONNS must be >= DB_MAX_IDLE_CONNS")
+		errs = append(errs, fmt.Errorf("DB_MAX_CONNS must be >= DB_MAX_IDLE_CONNS"))
 	}
 
 	if c.Example.Enabled {
 		if err := validateExamplePositivity(c.Example); err != nil {
-			return err
-		}
-		if c.Example.DefaultPageSize > c.Example.MaxPageSize {
-			return fmt.Errorf("EXAMPLE_DEFAULT_PAGE_SIZE must be <= EXAMPLE_MAX_PAGE_SIZE")
+			errs = append(errs, err)
+		} else if c.Example.DefaultPageSize > c.Example.MaxPageSize {
+			errs = append(errs, fmt.Errorf("EXAMPLE_DEFAULT_PAGE_SIZE must be <= EXAMPLE_MAX_PAGE_SIZE"))
 		}
 		if c.Example.MaxPageSize > exampleMaxPageSizeUpperBound {
-			return fmt.Errorf("EXAMPLE_MAX_PAGE_SIZE exceeds maximum allowed value %d", exampleMaxPageSizeUpperBound)
+			errs = append(errs, fmt.Errorf("EXAMPLE_MAX_PAGE_SIZE exceeds maximum allowed value %d", exampleMaxPageSizeUpperBound))
 		}
 		if c.Example.MaxNameLength > exampleMaxNameLengthUpperBound {
-			return fmt.Errorf("EXAMPLE_MAX_NAME_LENGTH exceeds maximum allowed value %d", exampleMaxNameLengthUpperBound)
+			errs = append(errs, fmt.Errorf("EXAMPLE_MAX_NAME_LENGTH exceeds maximum allowed value %d", exampleMaxNameLengthUpperBound))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// IsDevelopment reports whether the application runs in the development
+// environment.
+func (c *Config) IsDevelopment() bool {
+	return c.App.Environment == "development"
+}
+
+// IsProduction reports whether the application runs in the production
+// environment.
+func (c *Config) IsProduction() bool {
+	return c.App.Environment == "production"
+}
+
+// ProfilingEnabled reports whether the pprof endpoints should be registered:
+// always in development, otherwise only when HTTP_ENABLE_PROFILING is set.
+func (c *Config) ProfilingEnabled() bool {
+	return c.IsDevelopment() || c.HTTP.EnableProfiling
+}
+
+// StrictJSONEnabled reports whether JSON request bodies with unknown fields
+// are rejected: always in development, otherwise only when HTTP_STRICT_JSON
+// is set.
+func (c *Config) StrictJSONEnabled() bool {
+	return c.IsDevelopment() || c.HTTP.StrictJSON
 }
 
 // HTTPAddr returns the HTTP listen address.
@@ -229,6 +380,11 @@ func (c *Config) HTTPAddr() string {
 	return net.JoinHostPort(c.HTTP.Host, strconv.Itoa(c.HTTP.Port))
 }
 
+// AdminAddr returns the admin HTTP listen address.
+func (c *Config) AdminAddr() string {
+	return net.JoinHostPort(c.Admin.Host, strconv.Itoa(c.Admin.Port))
+}
+
 // GRPCAddr returns the gRPC listen address.
 func (c *Config) GRPCAddr() string {
 	return net.JoinHostPort(c.GRPC.Host, strconv.Itoa(c.GRPC.Port))
@@ -275,17 +431,35 @@ func setDefaults(v *viper.Viper) {
 		"http.cors_allow_origins":   []string{},
 		"http.cors_allow_methods":   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		"http.cors_allow_headers":   []string{"Authorization", "Content-Type", "X-Request-ID"},
+		"http.enable_profiling":     false,
+		"http.strict_json":          false,
+		"http.json_max_depth":       32,
+		"http.json_max_elements":    10000,
+		"http.compression_enabled":  true,
+		"http.allowed_hosts":        []string{},
+		"http.trusted_proxies":      []string{},
+		"http.slash_redirect":       true,
+		"http.compression_min_size": 1024,
+		"http.compression_level":    0,
+
+		"admin.enabled": false,
+		"admin.host":    "127.0.0.1",
+		"admin.port":    8081,
 
 		"grpc.host": defaultHost,
 		"grpc.port": 50051,
 
-		"db.ssl_mode":        "disable",
-		"db.max_conns":       10,
-		"db.max_idle_conns":  2,
-		"db.max_conn_idle":   30 * time.Minute,
-		"db.max_conn_life":   1 * time.Hour,
-		"db.connect_timeout": 5 * time.Second,
-
+		"db.ssl_mode":             "disable",
+		"db.max_conns":            10,
+		"db.max_idle_conns":       2,
+		"db.max_conn_idle":        30 * time.Minute,
+		"db.max_conn_life":        1 * time.Hour,
+		"db.connect_timeout":      5 * time.Second,
+		"db.connect_max_attempts": 5,
+		"db.connect_retry_delay":  1 * time.Second,
+		"db.verify_schema":        true,
+
+		"valkey.enabled":         true,
 		"valkey.db":              0,
 		"valkey.connect_timeout": 5 * time.Second,
 
@@ -296,10 +470,26 @@ func setDefaults(v *viper.Viper) {
 		"log.level":  "info",
 		"log.format": "json",
 
+		"log.include_caller": false,
+		"log.time_format":    "rfc3339",
+
+		"log.sampling_enabled":    false,
+		"log.sampling_level":      "info",
+		"log.sampling_window":     1 * time.Second,
+		"log.sampling_first":      100,
+		"log.sampling_thereafter": 100,
+
+		"feature_flags.cache_ttl": 30 * time.Second,
+		"feature_flags.static":    []string{},
+
 		"example.enabled":           false,
 		"example.default_page_size": int32(20),
 		"example.max_page_size":     int32(100),
 		"example.max_name_length":   int32(255),
+		"example.max_offset":        int32(10000),
+		"example.default_sort":      "created_at:desc",
+		"example.list_cache_ttl":    time.Duration(0),
+		"example.list_cache_stale":  5 * time.Second,
 	}
 
 	for key, value := range defaults {
@@ -325,8 +515,22 @@ func leafBindings() []leafBinding {
 		{"http.body_limit", "HTTP_BODY_LIMIT"},
 		{"http.health_probe_timeout", "HTTP_HEALTH_PROBE_TIMEOUT"},
 		{"http.cors_allow_origins", "HTTP_CORS_ALLOW_ORIGINS"},
+		{"http.allowed_hosts", "HTTP_ALLOWED_HOSTS"},
+		{"http.trusted_proxies", "HTTP_TRUSTED_PROXIES"},
+		{"http.slash_redirect", "HTTP_SLASH_REDIRECT"},
 		{"http.cors_allow_methods", "HTTP_CORS_ALLOW_METHODS"},
 		{"http.cors_allow_headers", "HTTP_CORS_ALLOW_HEADERS"},
+		{"http.enable_profiling", "HTTP_ENABLE_PROFILING"},
+		{"http.strict_json", "HTTP_STRICT_JSON"},
+		{"http.json_max_depth", "HTTP_JSON_MAX_DEPTH"},
+		{"http.json_max_elements", "HTTP_JSON_MAX_ELEMENTS"},
+		{"http.compression_enabled", "HTTP_COMPRESSION_ENABLED"},
+		{"http.compression_min_size", "HTTP_COMPRESSION_MIN_SIZE"},
+		{"http.compression_level", "HTTP_COMPRESSION_LEVEL"},
+
+		{"admin.enabled", "ADMIN_ENABLED"},
+		{"admin.host", "ADMIN_HOST"},
+		{"admin.port", "ADMIN_PORT"},
 
 		{"grpc.host", "GRPC_HOST"},
 		{"grpc.port", "GRPC_PORT"},
@@ -342,7 +546,11 @@ func leafBindings() []leafBinding {
 		{"db.max_conn_idle", "DB_MAX_CONN_IDLE"},
 		{"db.max_conn_life", "DB_MAX_CONN_LIFE"},
 		{"db.connect_timeout", "DB_CONNECT_TIMEOUT"},
+		{"db.connect_max_attempts", "DB_CONNECT_MAX_ATTEMPTS"},
+		{"db.connect_retry_delay", "DB_CONNECT_RETRY_DELAY"},
+		{"db.verify_schema", "DB_VERIFY_SCHEMA"},
 
+		{"valkey.enabled", "VALKEY_ENABLED"},
 		{"valkey.host", "VALKEY_HOST"},
 		{"valkey.port", "VALKEY_PORT"},
 		{"valkey.password", "VALKEY_PASSWORD"},
@@ -351,6 +559,16 @@ func leafBindings() []leafBinding {
 
 		{"log.level", "LOG_LEVEL"},
 		{"log.format", "LOG_FORMAT"},
+		{"log.include_caller", "LOG_INCLUDE_CALLER"},
+		{"log.time_format", "LOG_TIME_FORMAT"},
+		{"log.sampling_enabled", "LOG_SAMPLING_ENABLED"},
+		{"log.sampling_level", "LOG_SAMPLING_LEVEL"},
+		{"log.sampling_window", "LOG_SAMPLING_WINDOW"},
+		{"log.sampling_first", "LOG_SAMPLING_FIRST"},
+		{"log.sampling_thereafter", "LOG_SAMPLING_THEREAFTER"},
+
+		{"feature_flags.cache_ttl", "FEATURE_FLAGS_CACHE_TTL"},
+		{"feature_flags.static", "FEATURE_FLAGS_STATIC"},
 
 		{"otel.exporter", "OTEL_EXPORTER"},
 		{"otel.endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT"},
@@ -361,6 +579,10 @@ func leafBindings() []leafBinding {
 		{"example.default_page_size", "EXAMPLE_DEFAULT_PAGE_SIZE"},
 		{"example.max_page_size", "EXAMPLE_MAX_PAGE_SIZE"},
 		{"example.max_name_length", "EXAMPLE_MAX_NAME_LENGTH"},
+		{"example.max_offset", "EXAMPLE_MAX_OFFSET"},
+		{"example.default_sort", "EXAMPLE_DEFAULT_SORT"},
+		{"example.list_cache_ttl", "EXAMPLE_LIST_CACHE_TTL"},
+		{"example.list_cache_stale", "EXAMPLE_LIST_CACHE_STALE"},
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/validation"
 )
 
 func TestLoad_ExplicitConfigFileMissingFails(t *testing.T) {
@@ -125,6 +126,8 @@ otel:
 	t.Setenv("DB_NAME", "envdb")
 	t.Setenv("OTEL_SERVICE_NAME", "env-service")
 	t.Setenv("EXAMPLE_ENABLED", "false")
+	t.Setenv("DB_CONNECT_MAX_ATTEMPTS", "10")
+	t.Setenv("DB_CONNECT_RETRY_DELAY", "250ms")
 
 	cfg, err := config.Load()
 	require.NoError(t, err)
@@ -133,6 +136,8 @@ otel:
 	require.Equal(t, "env-app", cfg.App.Name)
 	require.Equal(t, 2222, cfg.HTTP.Port)
 	require.Equal(t, "envdb", cfg.DB.Name)
+	require.Equal(t, 10, cfg.DB.ConnectMaxAttempts)
+	require.Equal(t, 250*time.Millisecond, cfg.DB.ConnectRetryDelay)
 	require.Equal(t, "env-service", cfg.OTel.ServiceName)
 	require.False(t, cfg.Example.Enabled)
 }
@@ -220,7 +225,10 @@ otel:
 	require.Equal(t, int32(20), cfg.Example.DefaultPageSize)
 	require.Equal(t, int32(100), cfg.Example.MaxPageSize)
 	require.Equal(t, int32(255), cfg.Example.MaxNameLength)
+	require.Zero(t, cfg.Example.ListCacheTTL)
 	require.Equal(t, 5*time.Second, cfg.HTTP.HealthProbeTimeout)
+	require.Equal(t, 5, cfg.DB.ConnectMaxAttempts)
+	require.Equal(t, time.Second, cfg.DB.ConnectRetryDelay)
 }
 
 func TestValidate_InvalidEnvironment(t *testing.T) {
@@ -252,6 +260,18 @@ func TestValidate_OTLPWithoutEndpoint(t *testing.T) {
 	require.Contains(t, err.Error(), "OTEL_EXPORTER_OTLP_ENDPOINT is required when OTEL_EXPORTER=otlp")
 }
 
+func TestValidate_Valkey(t *testing.T) {
+	cfg := validConfig()
+	cfg.Valkey.Host = ""
+	cfg.Valkey.Port = 0
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "VALKEY_HOST is required when VALKEY_ENABLED=true")
+	require.ErrorContains(t, err, "VALKEY_PORT is required when VALKEY_ENABLED=true")
+
+	cfg.Valkey.Enabled = false
+	require.NoError(t, cfg.Validate())
+}
+
 func TestValidate_InvalidOTLPURL(t *testing.T) {
 	cfg := validConfig()
 	cfg.OTel.Exporter = "otlp"
@@ -341,6 +361,66 @@ func TestValidate_AcceptsValidConfig(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 }
 
+func TestValidate_TrustedProxies(t *testing.T) {
+	cfg := validConfig()
+	cfg.HTTP.TrustedProxies = []string{"10.0.0.0/8", "2001:db8::/32"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.HTTP.TrustedProxies = []string{"10.0.0.1"}
+	require.ErrorContains(t, cfg.Validate(), "trusted_proxies[0]")
+}
+
+func TestValidate_ProductionRejectsSampleDBPassword(t *testing.T) {
+	cfg := validConfig()
+	cfg.App.Environment = "production"
+	cfg.DB.Password = "postgres"
+	require.ErrorContains(t, cfg.Validate(), "DB_PASSWORD must not be the sample default in production")
+
+	cfg.DB.Password = "s3cret-from-vault"
+	require.NoError(t, cfg.Validate())
+
+	cfg.App.Environment = "staging"
+	cfg.DB.Password = "postgres"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestValidate_AdminListener(t *testing.T) {
+	cfg := validConfig()
+	cfg.Admin.Enabled = true
+	require.ErrorContains(t, cfg.Validate(), "ADMIN_PORT is required")
+
+	cfg.Admin.Port = cfg.HTTP.Port
+	require.ErrorContains(t, cfg.Validate(), "ADMIN_PORT must differ")
+
+	cfg.Admin.Port = 8081
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, ":8081", cfg.AdminAddr())
+}
+
+func TestProfilingEnabled(t *testing.T) {
+	cfg := validConfig()
+	require.False(t, cfg.ProfilingEnabled())
+
+	cfg.HTTP.EnableProfiling = true
+	require.True(t, cfg.ProfilingEnabled())
+
+	cfg.HTTP.EnableProfiling = false
+	cfg.App.Environment = "development"
+	require.True(t, cfg.ProfilingEnabled())
+}
+
+func TestStrictJSONEnabled(t *testing.T) {
+	cfg := validConfig()
+	require.False(t, cfg.StrictJSONEnabled())
+
+	cfg.HTTP.StrictJSON = true
+	require.True(t, cfg.StrictJSONEnabled())
+
+	cfg.HTTP.StrictJSON = false
+	cfg.App.Environment = "development"
+	require.True(t, cfg.StrictJSONEnabled())
+}
+
 func TestDBConnString(t *testing.T) {
 	cfg := validConfig()
 	cfg.DB.Password = "p@ss w#rd"
@@ -380,28 +460,33 @@ func validConfig() *config.Config {
 			IdleTimeout:        60 * time.Second,
 			BodyLimit:          "1M",
 			HealthProbeTimeout: 5 * time.Second,
+			JSONMaxDepth:       32,
+			JSONMaxElements:    10000,
 		},
 		GRPC: config.GRPCConfig{
 			Host: "127.0.0.1",
 			Port: 50051,
 		},
 		DB: config.DBConfig{
-			Host:           "127.0.0.1",
-			Port:           5432,
-			Name:           "app",
-			User:           "postgres",
-			Password:       "postgres",
-			SSLMode:        "disable",
-			MaxConns:       10,
-			MaxIdleConns:   2,
-			MaxConnIdle:    30 * time.Minute,
-			MaxConnLife:    1 * time.Hour,
-			ConnectTimeout: 5 * time.Second,
+			Host:               "127.0.0.1",
+			Port:               5432,
+			Name:               "app",
+			User:               "postgres",
+			Password:           "postgres",
+			SSLMode:            "disable",
+			MaxConns:           10,
+			MaxIdleConns:       2,
+			MaxConnIdle:        30 * time.Minute,
+			MaxConnLife:        1 * time.Hour,
+			ConnectTimeout:     5 * time.Second,
+			ConnectMaxAttempts: 5,
+			ConnectRetryDelay:  time.Second,
 		},
 		Valkey: config.ValkeyConfig{
-			Host: "127.0.0.1",
-			Port: 6379,
-			DB:   0,
+			Enabled: true,
+			Host:    "127.0.0.1",
+			Port:    6379,
+			DB:      0,
 		},
 		OTel: config.OTelConfig{
 			Exporter:    "none",
@@ -418,6 +503,87 @@ func validConfig() *config.Config {
 			DefaultPageSize: 20,
 			MaxPageSize:     100,
 			MaxNameLength:   255,
+			MaxOffset:       10000,
 		},
 	}
 }
+
+func TestLoad_RejectsDurationWithoutUnit(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	content := `
+app:
+  shutdown_timeout: 30
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(content), 0o600))
+	t.Setenv("CONFIG_FILE", cfgPath)
+	t.Setenv("HTTP_READ_TIMEOUT", "15")
+
+	_, err := config.Load()
+	require.ErrorContains(t, err, "'app.shutdown_timeout' duration 30 has no unit")
+	require.ErrorContains(t, err, `'http.read_timeout' invalid duration "15"`)
+}
+
+func TestValidate_AggregatesErrors(t *testing.T) {
+	cfg := validConfig()
+	cfg.App.Environment = "invalid"
+	cfg.DB.MaxConns = 1
+	cfg.DB.MaxIdleConns = 2
+	cfg.OTel.Exporter = "otlp"
+	cfg.OTel.Endpoint = ""
+
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "config validation failed")
+	require.ErrorContains(t, err, "DB_MAX_CONNS must be >= DB_MAX_IDLE_CONNS")
+	require.ErrorContains(t, err, "OTEL_EXPORTER_OTLP_ENDPOINT is required")
+}
+
+func TestValidate_MoneyCurrency(t *testing.T) {
+	type pricing struct {
+		Deposit config.Money `validate:"required"`
+	}
+	require.NoError(t, validation.Validator().Struct(pricing{Deposit: config.Money{Amount: 100, Currency: "THB"}}))
+	require.Error(t, validation.Validator().Struct(pricing{Deposit: config.Money{Amount: 100, Currency: "ABC"}}))
+}
+
+func TestParsePercent(t *testing.T) {
+	p, err := config.ParsePercent("12.5%")
+	require.NoError(t, err)
+	require.InDelta(t, 0.125, p.Fraction(), 1e-9)
+	require.Equal(t, "12.5%", p.String())
+
+	_, err = config.ParsePercent("150%")
+	require.ErrorContains(t, err, "out of range")
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want config.Money
+	}{
+		{"150 THB", config.Money{Amount: 15000, Currency: "THB"}},
+		{"150.5 THB", config.Money{Amount: 15050, Currency: "THB"}},
+		{"0.05 USD", config.Money{Amount: 5, Currency: "USD"}},
+		{"-0.50 EUR", config.Money{Amount: -50, Currency: "EUR"}},
+		{"1500 JPY", config.Money{Amount: 1500, Currency: "JPY"}},
+		{"1.25 KWD", config.Money{Amount: 1250, Currency: "KWD"}},
+		{"0.005 BHD", config.Money{Amount: 5, Currency: "BHD"}},
+	}
+	for _, tc := range tests {
+		got, err := config.ParseMoney(tc.in)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.want, got, tc.in)
+	}
+
+	for _, in := range []string{"150", "THB 150", "1.234 THB", "1.5 JPY", "1.2345 KWD", "150 thb", "99999999999999999999 USD"} {
+		_, err := config.ParseMoney(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	require.Equal(t, "-0.50 EUR", config.Money{Amount: -50, Currency: "EUR"}.String())
+	require.Equal(t, "150.50 THB", config.Money{Amount: 15050, Currency: "THB"}.String())
+	require.Equal(t, "1500 JPY", config.Money{Amount: 1500, Currency: "JPY"}.String())
+	require.Equal(t, "1.250 KWD", config.Money{Amount: 1250, Currency: "KWD"}.String())
+}
@@ -0,0 +1,81 @@
+// Decode hooks applied by Load.
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	percentType  = reflect.TypeFor[Percent]()
+	moneyType    = reflect.TypeFor[Money]()
+)
+
+// decodeHook parses durations, the typed values in types.go, and
+// comma-separated lists. The decoder wraps hook errors with the key, e.g.
+// "'app.shutdown_timeout' duration 30 has no unit".
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		durationHook,
+		percentHook,
+		moneyHook,
+		mapstructure.StringToWeakSliceHookFunc(","),
+	)
+}
+
+// durationHook parses strings as durations and rejects bare non-zero numbers,
+// which would otherwise silently decode as nanoseconds.
+func durationHook(from, to reflect.Type, data any) (any, error) {
+	if to != durationType || from == durationType {
+		return data, nil
+	}
+	switch from.Kind() { //nolint:exhaustive // other kinds fall through to the decoder.
+	case reflect.String:
+		s := strings.TrimSpace(data.(string)) //nolint:forcetypeassert // kind checked above.
+		if s == "" {
+			return time.Duration(0), nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: want a number with a unit such as \"30s\" or \"5m\"", s)
+		}
+		return d, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if reflect.ValueOf(data).IsZero() {
+			return time.Duration(0), nil
+		}
+		return nil, fmt.Errorf("duration %v has no unit: write it as a string such as \"%vs\"", data, data)
+	}
+	return data, nil
+}
+
+func percentHook(from, to reflect.Type, data any) (any, error) {
+	if to != percentType || from == percentType {
+		return data, nil
+	}
+	switch from.Kind() { //nolint:exhaustive // other kinds fall through to the decoder.
+	case reflect.String:
+		return ParsePercent(data.(string)) //nolint:forcetypeassert // kind checked above.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return percentInRange(float64(reflect.ValueOf(data).Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return percentInRange(float64(reflect.ValueOf(data).Uint()))
+	case reflect.Float32, reflect.Float64:
+		return percentInRange(reflect.ValueOf(data).Float())
+	}
+	return data, nil
+}
+
+func moneyHook(from, to reflect.Type, data any) (any, error) {
+	if to != moneyType || from.Kind() != reflect.String {
+		return data, nil
+	}
+	return ParseMoney(data.(string)) //nolint:forcetypeassert // kind checked above.
+}
@@ -0,0 +1,113 @@
+//go:build unit
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type typedSettings struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+	LateFee Percent       `mapstructure:"late_fee"`
+	Deposit Money         `mapstructure:"deposit"`
+	Origins []string      `mapstructure:"origins"`
+}
+
+func decodeTyped(t *testing.T, input map[string]any) (typedSettings, error) {
+	t.Helper()
+
+	var out typedSettings
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHook(),
+		WeaklyTypedInput: true,
+		Result:           &out,
+	})
+	require.NoError(t, err)
+	return out, dec.Decode(input)
+}
+
+func TestDecodeHook_Duration(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      any
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "string with unit", in: "30s", want: 30 * time.Second},
+		{name: "typed duration", in: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "zero", in: 0, want: 0},
+		{name: "empty string", in: "", want: 0},
+		{name: "bare int", in: 30, wantErr: "'timeout' duration 30 has no unit"},
+		{name: "bare float", in: 1.5, wantErr: "'timeout' duration 1.5 has no unit"},
+		{name: "bare numeric string", in: "30", wantErr: `'timeout' invalid duration "30"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := decodeTyped(t, map[string]any{"timeout": tc.in})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, out.Timeout)
+		})
+	}
+}
+
+func TestDecodeHook_Percent(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      any
+		want    Percent
+		wantErr string
+	}{
+		{name: "int", in: 15, want: 15},
+		{name: "float", in: 12.5, want: 12.5},
+		{name: "string with sign", in: "15%", want: 15},
+		{name: "string without sign", in: " 7.5 ", want: 7.5},
+		{name: "above range", in: 101, wantErr: "'late_fee' percent 101 is out of range"},
+		{name: "negative string", in: "-1%", wantErr: "'late_fee' percent -1 is out of range"},
+		{name: "not a number", in: "lots", wantErr: `'late_fee' invalid percent "lots"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := decodeTyped(t, map[string]any{"late_fee": tc.in})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, float64(tc.want), float64(out.LateFee), 1e-9)
+		})
+	}
+}
+
+func TestDecodeHook_Money(t *testing.T) {
+	out, err := decodeTyped(t, map[string]any{"deposit": "150.50 THB"})
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 15050, Currency: "THB"}, out.Deposit)
+
+	out, err = decodeTyped(t, map[string]any{"deposit": "1500 JPY"})
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 1500, Currency: "JPY"}, out.Deposit)
+
+	out, err = decodeTyped(t, map[string]any{"deposit": map[string]any{"amount": 999, "currency": "USD"}})
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 999, Currency: "USD"}, out.Deposit)
+
+	_, err = decodeTyped(t, map[string]any{"deposit": "150 baht"})
+	require.ErrorContains(t, err, `'deposit' invalid money "150 baht"`)
+
+	_, err = decodeTyped(t, map[string]any{"deposit": "150.5 JPY"})
+	require.ErrorContains(t, err, `'deposit' invalid money "150.5 JPY": JPY has 0 decimal places`)
+}
+
+func TestDecodeHook_KeepsCommaSeparatedLists(t *testing.T) {
+	out, err := decodeTyped(t, map[string]any{"origins": "https://a.example,https://b.example"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://a.example", "https://b.example"}, out.Origins)
+}
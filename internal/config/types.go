@@ -0,0 +1,110 @@
+// Typed configuration values decoded by the hooks in decode.go.
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Percent is a percentage in [0, 100]. It decodes from a number (15, 12.5)
+// or a string with an optional percent sign ("15%", "12.5").
+type Percent float64
+
+// Fraction returns p as a fraction of one (15% -> 0.15).
+func (p Percent) Fraction() float64 {
+	return float64(p) / 100
+}
+
+// String formats p with a percent sign.
+func (p Percent) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
+
+// ParsePercent parses s as a Percent.
+func ParsePercent(s string) (Percent, error) {
+	raw := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percent %q: want a number such as 15 or \"15%%\"", s)
+	}
+	return percentInRange(f)
+}
+
+func percentInRange(f float64) (Percent, error) {
+	if math.IsNaN(f) || f < 0 || f > 100 {
+		return 0, fmt.Errorf("percent %v is out of range: want 0 to 100", f)
+	}
+	return Percent(f), nil
+}
+
+// Money is an amount in the currency's minor units (cents, satang, fils)
+// with its ISO 4217 code. It decodes from a string such as "150.50 THB" or
+// from a map with amount (minor units) and currency keys. The code is
+// checked by Config.Validate.
+type Money struct {
+	Amount   int64  `mapstructure:"amount" yaml:"amount"`
+	Currency string `mapstructure:"currency" yaml:"currency" validate:"omitempty,iso4217"`
+}
+
+// minorDigits lists the ISO 4217 currencies whose minor unit is not a
+// hundredth; every other code has two decimal places.
+var minorDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyExponent returns the number of decimal places of the ISO 4217
+// currency code: 0 for JPY, 2 for THB, 3 for KWD.
+func CurrencyExponent(code string) int {
+	if d, ok := minorDigits[code]; ok {
+		return d
+	}
+	return 2
+}
+
+// String formats m in major units with the currency's decimal places, e.g.
+// "150.50 THB", "1500 JPY" or "1.250 KWD".
+func (m Money) String() string {
+	digits := CurrencyExponent(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if digits == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amount, m.Currency)
+	}
+	scale := int64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, digits, amount%scale, m.Currency)
+}
+
+var moneyPattern = regexp.MustCompile(`^(-?)(\d+)(?:\.(\d+))?\s+([A-Z]{3})$`)
+
+// ParseMoney parses s, an amount followed by an upper-case ISO 4217 code, as
+// Money. The amount may have at most as many decimal places as the currency
+// (none for JPY, three for KWD).
+func ParseMoney(s string) (Money, error) {
+	match := moneyPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Money{}, fmt.Errorf("invalid money %q: want an amount and currency such as \"150.50 THB\"", s)
+	}
+	negative, whole, frac, currency := match[1] == "-", match[2], match[3], match[4]
+	digits := CurrencyExponent(currency)
+	if len(frac) > digits {
+		return Money{}, fmt.Errorf("invalid money %q: %s has %d decimal places", s, currency, digits)
+	}
+	minor, err := strconv.ParseInt(whole+frac+strings.Repeat("0", digits-len(frac)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money %q: amount out of range", s)
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
@@ -3,6 +3,8 @@
 package di
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/samber/do/v2"
@@ -14,18 +16,41 @@ import (
 	httphandler "github.com/zercle/zercle-go-template/internal/features/example/handler/http"
 	"github.com/zercle/zercle-go-template/internal/features/example/repository"
 	"github.com/zercle/zercle-go-template/internal/features/example/service"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
 	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/events"
+	"github.com/zercle/zercle-go-template/pkg/readthrough"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 
 	"github.com/labstack/echo/v5"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
+// errOffsetTooLarge tells clients why a deep page was refused, since the
+// generic invalid-input message would not.
+var errOffsetTooLarge = &sharederrors.AppError{
+	Code:       sharederrors.ErrInvalidInput.Code,
+	Message:    "offset is too large; narrow the listing with sort or filters instead of paging this deep",
+	HTTPStatus: sharederrors.ErrInvalidInput.HTTPStatus,
+	GRPCCode:   sharederrors.ErrInvalidInput.GRPCCode,
+}
+
+// listCache caches item listings for EXAMPLE_LIST_CACHE_TTL. It is provided
+// only when that TTL is positive.
+type listCache = readthrough.Cache[[]domain.Item]
+
 // Register wires the example feature into the composition root.
 func Register(c do.Injector) error {
 	sharederrors.RegisterSentinel(domain.ErrItemNotFound, sharederrors.ErrNotFound)
 	sharederrors.RegisterSentinel(domain.ErrInvalidName, sharederrors.ErrInvalidInput)
 	sharederrors.RegisterSentinel(domain.ErrInvalidID, sharederrors.ErrInvalidInput)
+	sharederrors.RegisterSentinel(domain.ErrInvalidSort, sharederrors.ErrInvalidInput)
+	sharederrors.RegisterSentinel(domain.ErrInvalidFields, sharederrors.ErrInvalidInput)
+	sharederrors.RegisterSentinel(domain.ErrOffsetTooLarge, errOffsetTooLarge)
 
 	do.Provide(c, func(i do.Injector) (domain.Repository, error) {
 		gormDB, err := do.Invoke[*gorm.DB](i)
@@ -35,16 +60,77 @@ func Register(c do.Injector) error {
 		return repository.NewRepository(gormDB), nil
 	})
 
+	if err := db.AddSchemaCheck(c, "example", func(ctx context.Context) error {
+		gormDB, err := do.Invoke[*gorm.DB](c)
+		if err != nil {
+			return fmt.Errorf("resolve gorm db: %w", err)
+		}
+		return repository.NewRepository(gormDB).Verify(ctx)
+	}); err != nil {
+		return err
+	}
+
+	cfg, err := do.Invoke[*config.Config](c)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+	if cfg.Example.ListCacheTTL > 0 {
+		do.Provide(c, func(i do.Injector) (*listCache, error) {
+			clk, err := do.Invoke[clock.Clock](i)
+			if err != nil {
+				return nil, fmt.Errorf("resolve clock: %w", err)
+			}
+			cacheOpts := []readthrough.Option{
+				readthrough.WithClock(clk.Now),
+				readthrough.WithStaleWhileRevalidate(cfg.Example.ListCacheStale),
+			}
+			if mp, err := do.Invoke[*metric.MeterProvider](i); err == nil {
+				cacheOpts = append(cacheOpts, readthrough.WithMeter(mp.Meter("example")))
+			}
+			cache, err := readthrough.New[[]domain.Item]("example_items", cfg.Example.ListCacheTTL, cacheOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("create example list cache: %w", err)
+			}
+			return cache, nil
+		})
+	}
+
 	do.Provide(c, func(i do.Injector) (domain.Service, error) {
 		repo, err := do.Invoke[domain.Repository](i)
 		if err != nil {
 			return nil, fmt.Errorf("resolve example repository: %w", err)
 		}
-		cfg, err := do.Invoke[*config.Config](i)
+
+		clk, err := do.Invoke[clock.Clock](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve clock: %w", err)
+		}
+
+		bus, err := do.Invoke[*events.Bus](i)
 		if err != nil {
-			return nil, fmt.Errorf("resolve config: %w", err)
+			return nil, fmt.Errorf("resolve event bus: %w", err)
 		}
-		return service.NewService(repo, cfg.Example.DefaultPageSize, cfg.Example.MaxPageSize, cfg.Example.MaxNameLength), nil
+
+		defaultSort, err := sorting.Parse(cfg.Example.DefaultSort, domain.SortableFields...)
+		if err != nil {
+			return nil, fmt.Errorf("parse EXAMPLE_DEFAULT_SORT: %w", err)
+		}
+
+		opts := []service.Option{
+			service.WithClock(clk),
+			service.WithEvents(bus),
+			service.WithMaxOffset(cfg.Example.MaxOffset),
+			service.WithDefaultSort(defaultSort),
+		}
+		cache, err := do.Invoke[*listCache](i)
+		switch {
+		case err == nil:
+			opts = append(opts, service.WithListCache(cache))
+		case !errors.Is(err, do.ErrServiceNotFound):
+			return nil, fmt.Errorf("resolve example list cache: %w", err)
+		}
+
+		return service.NewService(repo, cfg.Example.DefaultPageSize, cfg.Example.MaxPageSize, cfg.Example.MaxNameLength, opts...), nil
 	})
 
 	do.Provide(c, func(i do.Injector) (*httphandler.Handler, error) {
@@ -84,5 +170,27 @@ func Register(c do.Injector) error {
 	}
 	pb.RegisterExampleServiceServer(gs, grpcHandler)
 
+	return registerAdmin(c)
+}
+
+// registerAdmin mounts DELETE /admin/cache/items when both the admin
+// listener and the list cache exist.
+func registerAdmin(c do.Injector) error {
+	admin, err := do.InvokeNamed[*echo.Echo](c, server.AdminHTTPName)
+	if errors.Is(err, do.ErrServiceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("resolve admin echo: %w", err)
+	}
+	cache, err := do.Invoke[*listCache](c)
+	if errors.Is(err, do.ErrServiceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("resolve example list cache: %w", err)
+	}
+	httphandler.NewCacheAdmin(cache).Register(admin.Group("/admin"))
+
 	return nil
 }
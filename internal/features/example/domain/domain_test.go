@@ -15,18 +15,20 @@ import (
 )
 
 func TestItem_Rename(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 	item := &domain.Item{
 		ID:        uuid.New(),
 		Name:      "original",
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC().Add(-1 * time.Hour),
+		CreatedAt: created,
+		UpdatedAt: created,
 	}
 
-	before := item.UpdatedAt
-	item.Rename("renamed")
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+	item.Rename("renamed", now)
 
 	assert.Equal(t, "renamed", item.Name)
-	assert.True(t, item.UpdatedAt.After(before), "expected UpdatedAt to advance")
+	assert.Equal(t, now.UTC(), item.UpdatedAt)
+	assert.Equal(t, time.UTC, item.UpdatedAt.Location())
 }
 
 func TestSentinelErrors(t *testing.T) {
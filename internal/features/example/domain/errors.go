@@ -9,4 +9,10 @@ var (
 	ErrItemNotFound = errors.New("item not found")
 	ErrInvalidName  = errors.New("item name is invalid")
 	ErrInvalidID    = errors.New("item id is invalid")
+	ErrInvalidSort  = errors.New("item sort is invalid")
+	// ErrInvalidFields rejects a field selection naming unknown fields.
+	ErrInvalidFields = errors.New("item fields are invalid")
+	// ErrOffsetTooLarge rejects pages deeper than the configured maximum
+	// offset.
+	ErrOffsetTooLarge = errors.New("item offset is too large")
 )
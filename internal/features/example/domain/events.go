@@ -0,0 +1,12 @@
+// STUB FEATURE — delete internal/features/example to start your project.
+
+package domain
+
+// ItemCreated is published on the in-process event bus after an item is
+// persisted.
+type ItemCreated struct {
+	Item Item
+}
+
+// Topic implements events.Event.
+func (ItemCreated) Topic() string { return "example.item_created" }
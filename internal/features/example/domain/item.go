@@ -16,8 +16,8 @@ type Item struct {
 	UpdatedAt time.Time
 }
 
-// Rename updates the item name and refreshes the updated-at timestamp.
-func (i *Item) Rename(name string) {
+// Rename updates the item name and sets the updated-at timestamp to now.
+func (i *Item) Rename(name string, now time.Time) {
 	i.Name = name
-	i.UpdatedAt = time.Now().UTC()
+	i.UpdatedAt = now.UTC()
 }
@@ -0,0 +1,17 @@
+// STUB FEATURE — delete internal/features/example to start your project.
+
+package domain
+
+import "github.com/zercle/zercle-go-template/pkg/sorting"
+
+// SortableFields lists the item fields clients may sort a listing by.
+var SortableFields = []string{"created_at", "name"}
+
+// ListQuery describes a single page of items.
+type ListQuery struct {
+	Limit  int32
+	Offset int32
+	// Sort is the requested ordering, already validated against
+	// SortableFields. Empty means newest first.
+	Sort []sorting.Key
+}
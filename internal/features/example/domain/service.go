@@ -14,5 +14,5 @@ import (
 type Service interface {
 	Create(ctx context.Context, name string) (*Item, error)
 	Get(ctx context.Context, id uuid.UUID) (*Item, error)
-	List(ctx context.Context, limit, offset int32) ([]Item, error)
+	List(ctx context.Context, q ListQuery) ([]Item, error)
 }
@@ -2,15 +2,18 @@
 
 package dto
 
+import "encoding/xml"
+
 // CreateItemRequest is the payload for creating a new item.
 type CreateItemRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=255"`
 }
 
-// ItemResponse is the JSON representation of an item.
+// ItemResponse is the JSON (and XML) representation of an item.
 type ItemResponse struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	XMLName   xml.Name `json:"-" xml:"item"`
+	ID        string   `json:"id" xml:"id"`
+	Name      string   `json:"name" xml:"name"`
+	CreatedAt string   `json:"created_at" xml:"created_at"`
+	UpdatedAt string   `json:"updated_at" xml:"updated_at"`
 }
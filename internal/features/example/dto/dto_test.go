@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zercle/zercle-go-template/internal/features/example/dto"
+	"github.com/zercle/zercle-go-template/internal/testutil"
 )
 
 func TestCreateItemRequest_Validation(t *testing.T) {
@@ -41,3 +42,52 @@ func TestListItemsRequest_Validation(t *testing.T) {
 	negativeOffset := dto.ListItemsRequest{Limit: 10, Offset: -1}
 	assert.Error(t, v.Struct(negativeOffset))
 }
+
+// TestDTOs_JSONFields pins the JSON field set of every example DTO so a
+// renamed, untagged, or colliding field fails here rather than in a client.
+func TestDTOs_JSONFields(t *testing.T) {
+	item := dto.ItemResponse{ID: "id", Name: "name", CreatedAt: "created", UpdatedAt: "updated"}
+
+	tests := []struct {
+		name string
+		dto  any
+		want []string
+	}{
+		{"CreateItemRequest", dto.CreateItemRequest{Name: "name"}, []string{"name"}},
+		{"ListItemsRequest", dto.ListItemsRequest{Limit: 10, Offset: 5, Sort: "name:asc", Fields: "id"}, []string{"fields", "limit", "offset", "sort"}},
+		{"ItemResponse", item, []string{"created_at", "id", "name", "updated_at"}},
+		{"ListItemsResponse", dto.ListItemsResponse{Items: []dto.ItemResponse{item}}, []string{"items"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, testutil.JSONFields(t, tc.dto))
+		})
+	}
+}
+
+// TestDTOs_Golden compares the marshaled response DTOs with golden files.
+// Regenerate with UPDATE_GOLDEN=1 after an intentional contract change.
+func TestDTOs_Golden(t *testing.T) {
+	item := dto.ItemResponse{
+		ID:        "0b9c4d8e-3f55-4c6e-9a0e-6f1f0c1d2e3f",
+		Name:      "widget",
+		CreatedAt: "2026-01-02T03:04:05Z",
+		UpdatedAt: "2026-01-02T03:04:05Z",
+	}
+
+	tests := []struct {
+		name string
+		dto  any
+	}{
+		{"item_response", item},
+		{"list_items_response", dto.ListItemsResponse{Items: []dto.ItemResponse{item}}},
+		{"list_items_response_empty", dto.ListItemsResponse{Items: []dto.ItemResponse{}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testutil.GoldenJSON(t, tc.name, tc.dto)
+		})
+	}
+}
@@ -2,13 +2,22 @@
 
 package dto
 
+import "encoding/xml"
+
 // ListItemsRequest carries pagination parameters for listing items.
 type ListItemsRequest struct {
 	Limit  int32 `json:"limit" query:"limit" validate:"omitempty,min=0,max=100"`
 	Offset int32 `json:"offset" query:"offset" validate:"omitempty,min=0"`
+	// Sort is a comma-separated list of field[:asc|desc] keys, e.g.
+	// "name:asc,created_at:desc".
+	Sort string `json:"sort" query:"sort"`
+	// Fields is a comma-separated list of ItemResponse fields to return,
+	// e.g. "id,name". Empty returns every field.
+	Fields string `json:"fields" query:"fields"`
 }
 
 // ListItemsResponse wraps a page of items.
 type ListItemsResponse struct {
-	Items []ItemResponse `json:"items"`
+	XMLName xml.Name       `json:"-" xml:"items"`
+	Items   []ItemResponse `json:"items" xml:"item"`
 }
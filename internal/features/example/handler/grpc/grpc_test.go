@@ -90,7 +90,7 @@ func TestServer_ListItems(t *testing.T) {
 
 	id := uuid.New()
 	items := []domain.Item{{ID: id, Name: "grpc-item", CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}}
-	svc.EXPECT().List(gomock.Any(), int32(10), int32(0)).Return(items, nil)
+	svc.EXPECT().List(gomock.Any(), domain.ListQuery{Limit: 10}).Return(items, nil)
 
 	resp, err := server.ListItems(context.Background(), &pb.ListItemsRequest{Limit: 10, Offset: 0})
 	require.NoError(t, err)
@@ -65,7 +65,7 @@ func (s *Server) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.L
 		return nil, sharederrors.GRPCErr(sharederrors.ErrInvalidInput)
 	}
 
-	items, err := s.service.List(ctx, req.Limit, req.Offset)
+	items, err := s.service.List(ctx, domain.ListQuery{Limit: req.Limit, Offset: req.Offset})
 	if err != nil {
 		return nil, sharederrors.GRPCErr(err)
 	}
@@ -0,0 +1,38 @@
+// STUB FEATURE — delete internal/features/example to start your project.
+
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+// Invalidator drops cached data, such as a readthrough.Cache.
+type Invalidator interface {
+	Invalidate()
+}
+
+// CacheAdmin exposes the item listing cache on the admin listener so
+// operators can drop it after bulk changes made outside the service.
+type CacheAdmin struct {
+	cache Invalidator
+}
+
+// NewCacheAdmin returns the admin handler for cache.
+func NewCacheAdmin(cache Invalidator) *CacheAdmin {
+	return &CacheAdmin{cache: cache}
+}
+
+// Register mounts the admin routes on the provided /admin group.
+func (a *CacheAdmin) Register(g *echo.Group) {
+	g.DELETE("/cache/items", a.Invalidate)
+}
+
+// Invalidate handles DELETE /admin/cache/items. The next listing of every
+// page is loaded from the repository.
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func (a *CacheAdmin) Invalidate(c *echo.Context) error {
+	a.cache.Invalidate()
+	return c.NoContent(http.StatusNoContent)
+}
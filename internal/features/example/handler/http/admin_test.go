@@ -0,0 +1,34 @@
+//go:build unit
+
+// STUB FEATURE — delete internal/features/example to start your project.
+
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	httphandler "github.com/zercle/zercle-go-template/internal/features/example/handler/http"
+)
+
+type countingInvalidator struct{ calls int }
+
+func (c *countingInvalidator) Invalidate() { c.calls++ }
+
+func TestCacheAdmin_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	cache := &countingInvalidator{}
+	e := echo.New()
+	httphandler.NewCacheAdmin(cache).Register(e.Group("/admin"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/cache/items", nil))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, 1, cache.calls)
+}
@@ -4,4 +4,6 @@ package httphandler
 
 import "time"
 
+// timeFormat renders response timestamps. Times are converted to UTC first so
+// they always end in "Z".
 const timeFormat = time.RFC3339
@@ -5,14 +5,21 @@ package httphandler
 import (
 	"net/http"
 
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
 
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
 	"github.com/zercle/zercle-go-template/internal/features/example/dto"
 	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+	"github.com/zercle/zercle-go-template/internal/shared/response"
+	"github.com/zercle/zercle-go-template/pkg/fieldmask"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
+// itemFields are the names the fields query parameter may select.
+var itemFields = fieldmask.Allowed[dto.ItemResponse]()
+
 // Handler exposes the example domain service over HTTP.
 type Handler struct {
 	service domain.Service
@@ -27,23 +34,23 @@ func New(service domain.Service) *Handler {
 func (h *Handler) Register(g *echo.Group) {
 	g.POST("/items", h.Create)
 	g.GET("/items", h.List)
-	g.GET("/items/:id", h.Get)
+	g.GET("/items/:id", h.Get, middleware.UUIDParam("id", domain.ErrInvalidID))
 }
 
 // NOTE: Echo v5 changed echo.Context from an interface (v4) to a struct, and
 // echo.HandlerFunc is now `func(c *Context) error`. Handlers therefore take
 // *echo.Context — this is correct for v5, not a mistake.
 
-// Create handles POST /items.
+// Create handles POST /items. Unknown body fields are rejected.
 // nolint:wrapcheck // echo handlers return the JSON write error directly.
 func (h *Handler) Create(c *echo.Context) error {
 	var req dto.CreateItemRequest
-	if err := c.Bind(&req); err != nil {
-		status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+	if err := request.BindStrict(c, &req); err != nil {
+		status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
 		return c.JSON(status, body)
 	}
 	if err := c.Validate(req); err != nil {
-		status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+		status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
 		return c.JSON(status, body)
 	}
 
@@ -56,12 +63,16 @@ func (h *Handler) Create(c *echo.Context) error {
 	return c.JSON(http.StatusCreated, mapItemToResponse(item))
 }
 
-// Get handles GET /items/:id.
+// Get handles GET /items/:id. The id is parsed and validated by the
+// middleware.UUIDParam route middleware, which answers bad ids with
+// domain.ErrInvalidID. The item is rendered as XML when the Accept header
+// prefers it.
 // nolint:wrapcheck // echo handlers return the JSON write error directly.
 func (h *Handler) Get(c *echo.Context) error {
-	id, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		status, body := sharederrors.HTTPError(domain.ErrInvalidID)
+	id, ok := middleware.UUIDParamFromContext(c, "id")
+	if !ok {
+		// The route was mounted without UUIDParam: a wiring bug, not bad input.
+		status, body := sharederrors.HTTPError(sharederrors.ErrInternal)
 		return c.JSON(status, body)
 	}
 
@@ -71,29 +82,66 @@ func (h *Handler) Get(c *echo.Context) error {
 		return c.JSON(status, body)
 	}
 
-	return c.JSON(http.StatusOK, mapItemToResponse(item))
+	return response.Negotiate(c, http.StatusOK, mapItemToResponse(item))
 }
 
-// List handles GET /items.
+// List handles GET /items. The optional sort query parameter takes
+// comma-separated field[:asc|desc] keys over domain.SortableFields. Like Get,
+// it negotiates XML via the Accept header. The optional fields query
+// parameter trims each item to the named ItemResponse fields; trimmed items
+// have no XML form, so such a request that prefers XML gets 406.
 // nolint:wrapcheck // echo handlers return the JSON write error directly.
 func (h *Handler) List(c *echo.Context) error {
 	var req dto.ListItemsRequest
 	if err := c.Bind(&req); err != nil {
-		status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+		status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
 		return c.JSON(status, body)
 	}
 	if err := c.Validate(req); err != nil {
-		status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+		status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
 		return c.JSON(status, body)
 	}
 
-	items, err := h.service.List(c.Request().Context(), req.Limit, req.Offset)
+	sort, err := sorting.Parse(req.Sort, domain.SortableFields...)
+	if err != nil {
+		status, body := sharederrors.HTTPError(domain.ErrInvalidSort)
+		return c.JSON(status, body)
+	}
+	mask, err := fieldmask.Parse(req.Fields, itemFields...)
+	if err != nil {
+		status, body := sharederrors.HTTPError(domain.ErrInvalidFields)
+		return c.JSON(status, body)
+	}
+	if mask != nil {
+		c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+		if response.Preferred(c.Request().Header.Get(echo.HeaderAccept)) == response.XML {
+			status, body := sharederrors.HTTPError(sharederrors.ErrNotAcceptable)
+			return c.JSON(status, body)
+		}
+	}
+
+	items, err := h.service.List(c.Request().Context(), domain.ListQuery{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Sort:   sort,
+	})
 	if err != nil {
 		status, body := sharederrors.HTTPError(err)
 		return c.JSON(status, body)
 	}
 
-	return c.JSON(http.StatusOK, mapItemsToResponse(items))
+	resp := mapItemsToResponse(items)
+	if mask == nil {
+		return response.Negotiate(c, http.StatusOK, resp)
+	}
+	selected := make([]map[string]any, len(resp.Items))
+	for i, item := range resp.Items {
+		if selected[i], err = mask.Apply(item); err != nil {
+			status, body := sharederrors.HTTPError(err)
+			return c.JSON(status, body)
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]any{"items": selected})
 }
 
 func mapItemToResponse(item *domain.Item) dto.ItemResponse {
@@ -103,8 +151,8 @@ func mapItemToResponse(item *domain.Item) dto.ItemResponse {
 	return dto.ItemResponse{
 		ID:        item.ID.String(),
 		Name:      item.Name,
-		CreatedAt: item.CreatedAt.Format(timeFormat),
-		UpdatedAt: item.UpdatedAt.Format(timeFormat),
+		CreatedAt: item.CreatedAt.UTC().Format(timeFormat),
+		UpdatedAt: item.UpdatedAt.UTC().Format(timeFormat),
 	}
 }
 
@@ -8,11 +8,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -21,9 +24,11 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
+	"github.com/zercle/zercle-go-template/internal/features/example/dto"
 	httphandler "github.com/zercle/zercle-go-template/internal/features/example/handler/http"
 	"github.com/zercle/zercle-go-template/internal/features/example/service/mock"
 	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
 // registerSentinelsOnce registers the example feature's domain sentinels exactly
@@ -38,6 +43,9 @@ func setupTest(t *testing.T) (*echo.Echo, *mock.MockService) {
 		sharederrors.RegisterSentinel(domain.ErrItemNotFound, sharederrors.ErrNotFound)
 		sharederrors.RegisterSentinel(domain.ErrInvalidName, sharederrors.ErrInvalidInput)
 		sharederrors.RegisterSentinel(domain.ErrInvalidID, sharederrors.ErrInvalidInput)
+		sharederrors.RegisterSentinel(domain.ErrInvalidSort, sharederrors.ErrInvalidInput)
+		sharederrors.RegisterSentinel(domain.ErrInvalidFields, sharederrors.ErrInvalidInput)
+		sharederrors.RegisterSentinel(domain.ErrOffsetTooLarge, sharederrors.ErrInvalidInput)
 	})
 
 	e := echo.New()
@@ -82,6 +90,26 @@ func TestHandler_Create(t *testing.T) {
 	require.Contains(t, rec.Body.String(), "stub")
 }
 
+func TestHandler_Create_RejectsUnknownField(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, _ := setupTest(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/api/v1/items", bytes.NewReader([]byte(`{"name":"stub","nmae":"typo"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "INVALID_INPUT", body["error"])
+	require.Equal(t, `unknown field "nmae"`, body["message"])
+}
+
 func TestHandler_Get(t *testing.T) {
 	t.Parallel()
 
@@ -99,6 +127,117 @@ func TestHandler_Get(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestHandler_Get_XML(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	id := uuid.New()
+
+	svc.EXPECT().Get(ctx, id).Return(&domain.Item{ID: id, Name: "found"}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items/"+id.String(), nil)
+	req.Header.Set("Accept", "application/xml")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "application/xml")
+
+	var body dto.ItemResponse
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "item", body.XMLName.Local)
+	require.Equal(t, id.String(), body.ID)
+	require.Equal(t, "found", body.Name)
+}
+
+func TestHandler_Get_DefaultsToJSON(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	id := uuid.New()
+
+	svc.EXPECT().Get(ctx, id).Return(&domain.Item{ID: id, Name: "found"}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items/"+id.String(), nil)
+	req.Header.Set("Accept", "*/*")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+	var body dto.ItemResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, id.String(), body.ID)
+}
+
+func TestHandler_List_XML(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+
+	svc.EXPECT().List(ctx, domain.ListQuery{}).Return([]domain.Item{{ID: uuid.New(), Name: "a"}, {ID: uuid.New(), Name: "b"}}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items", nil)
+	req.Header.Set("Accept", "text/xml")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body dto.ListItemsResponse
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Items, 2)
+	require.Equal(t, "a", body.Items[0].Name)
+}
+
+func TestHandler_Get_TimestampsInUTC(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	id := uuid.New()
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	at := time.Date(2026, 1, 2, 10, 4, 5, 0, bangkok)
+
+	svc.EXPECT().Get(ctx, id).Return(&domain.Item{ID: id, Name: "found", CreatedAt: at, UpdatedAt: at}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items/"+id.String(), nil)
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body dto.ItemResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "2026-01-02T03:04:05Z", body.CreatedAt)
+	require.Equal(t, "2026-01-02T03:04:05Z", body.UpdatedAt)
+}
+
+func TestHandler_List_EmptyIsArray(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+
+	svc.EXPECT().List(ctx, domain.ListQuery{}).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items", nil)
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"items":[]}`, rec.Body.String())
+}
+
 func TestHandler_Get_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -120,6 +259,53 @@ func TestHandler_Get_NotFound(t *testing.T) {
 	require.Equal(t, "NOT_FOUND", body["error"])
 }
 
+func TestHandler_Get_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"malformed", "not-a-uuid"},
+		{"nil uuid", uuid.Nil.String()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			// The service mock has no expectations: reaching it fails the test.
+			e, _ := setupTest(t)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items/"+tc.id, nil)
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, "INVALID_INPUT", body["error"])
+		})
+	}
+}
+
+func TestHandler_Get_WithoutUUIDParamIsInternalError(t *testing.T) {
+	t.Parallel()
+
+	// The service mock has no expectations: reaching it fails the test.
+	_, svc := setupTest(t)
+	e := echo.New()
+	e.GET("/items/:id", httphandler.New(svc).Get)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/"+uuid.NewString(), nil))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
 func TestHandler_Create_EmptyName(t *testing.T) {
 	t.Parallel()
 
@@ -162,7 +348,7 @@ func TestHandler_List_NoQueryParams(t *testing.T) {
 	ctx := context.Background()
 	e, svc := setupTest(t)
 
-	svc.EXPECT().List(ctx, int32(0), int32(0)).Return([]domain.Item{{ID: uuid.New(), Name: "default"}}, nil)
+	svc.EXPECT().List(ctx, domain.ListQuery{}).Return([]domain.Item{{ID: uuid.New(), Name: "default"}}, nil)
 
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items", nil)
@@ -171,3 +357,158 @@ func TestHandler_List_NoQueryParams(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, rec.Code)
 }
+
+func TestHandler_List_WithSort(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+
+	svc.EXPECT().List(ctx, domain.ListQuery{
+		Limit: 5,
+		Sort: []sorting.Key{
+			{Field: "name", Direction: sorting.Desc},
+			{Field: "created_at", Direction: sorting.Asc},
+		},
+	}).Return([]domain.Item{}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?limit=5&sort=name:desc,created_at", nil)
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_List_InvalidSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sort string
+	}{
+		{"unknown field", "updated_at"},
+		{"unknown direction", "name:sideways"},
+		{"duplicate field", "name,name:desc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			e, _ := setupTest(t)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?sort="+tc.sort, nil)
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, "INVALID_INPUT", body["error"])
+		})
+	}
+}
+
+func TestHandler_List_OffsetTooLarge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	svc.EXPECT().List(gomock.Any(), domain.ListQuery{Offset: 1_000_000_000}).
+		Return(nil, fmt.Errorf("%w: offset 1000000000 exceeds 10000", domain.ErrOffsetTooLarge))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?offset=1000000000", nil)
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "INVALID_INPUT", body["error"])
+}
+
+func TestHandler_List_Fields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	id := uuid.New()
+	svc.EXPECT().List(ctx, domain.ListQuery{}).Return([]domain.Item{{ID: id, Name: "a"}}, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?fields=id,name", nil)
+	req.Header.Set("Accept", "application/xml;q=0.5, application/json")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "Accept", rec.Header().Get("Vary"))
+	require.JSONEq(t, `{"items":[{"id":"`+id.String()+`","name":"a"}]}`, rec.Body.String())
+}
+
+func TestHandler_List_FieldsPreferringXMLIsNotAcceptable(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// The service mock has no expectations: reaching it fails the test.
+	e, _ := setupTest(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?fields=id,name", nil)
+	req.Header.Set("Accept", "application/xml")
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotAcceptable, rec.Code)
+	require.Equal(t, "Accept", rec.Header().Get("Vary"))
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "NOT_ACCEPTABLE", body["error"])
+}
+
+func TestHandler_List_FieldsEmptyIsArray(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, svc := setupTest(t)
+	svc.EXPECT().List(ctx, domain.ListQuery{}).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?fields=id", nil)
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"items":[]}`, rec.Body.String())
+}
+
+func TestHandler_List_InvalidFields(t *testing.T) {
+	t.Parallel()
+
+	for _, fields := range []string{"password", "id,,name", "name.first"} {
+		t.Run(fields, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			e, _ := setupTest(t)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/items?fields="+fields, nil)
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, "INVALID_INPUT", body["error"])
+		})
+	}
+}
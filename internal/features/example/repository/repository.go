@@ -11,9 +11,21 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/db/models"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
+// defaultListOrder is the ORDER BY used when a listing requests no sort.
+const defaultListOrder = "created_at DESC, id DESC"
+
+// sortColumns maps domain.SortableFields to item columns. Only these
+// identifiers are ever rendered into an ORDER BY clause.
+var sortColumns = sorting.Columns{
+	"created_at": "created_at",
+	"name":       "name",
+}
+
 // Repository is a GORM implementation of the domain.Repository port.
 type Repository struct {
 	db *gorm.DB
@@ -24,6 +36,15 @@ func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// Verify checks that the items table has every column the model maps. It is
+// registered as the example feature's startup schema check.
+func (r *Repository) Verify(ctx context.Context) error {
+	if err := db.VerifyColumns(ctx, r.db, &[]models.Item{}); err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	return nil
+}
+
 // Create persists a new item.
 func (r *Repository) Create(ctx context.Context, item *domain.Item) error {
 	if item == nil {
@@ -50,15 +71,24 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Item, e
 	return mapModelToDomain(&m), nil
 }
 
-// List returns a paginated slice of items ordered by created_at descending,
-// then by id descending to keep order stable across pages with identical
-// timestamps.
-func (r *Repository) List(ctx context.Context, limit, offset int32) ([]domain.Item, error) {
+// List returns a paginated slice of items. Without an explicit sort they are
+// ordered by created_at descending; in every case id is appended as a
+// tie-breaker to keep order stable across pages with identical sort values.
+func (r *Repository) List(ctx context.Context, q domain.ListQuery) ([]domain.Item, error) {
+	order := defaultListOrder
+	if len(q.Sort) > 0 {
+		var err error
+		order, err = sorting.OrderBy(q.Sort, sortColumns, "id")
+		if err != nil {
+			return nil, fmt.Errorf("list items: %w: %w", domain.ErrInvalidSort, err)
+		}
+	}
+
 	var ms []models.Item
 	if err := r.db.WithContext(ctx).
-		Order("created_at DESC, id DESC").
-		Limit(int(limit)).
-		Offset(int(offset)).
+		Order(order).
+		Limit(int(q.Limit)).
+		Offset(int(q.Offset)).
 		Find(&ms).Error; err != nil {
 		return nil, fmt.Errorf("list items: %w", err)
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/zercle/zercle-go-template/internal/features/example/repository"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/db/migrations"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
 type RepositoryIntegrationSuite struct {
@@ -114,11 +115,61 @@ func (s *RepositoryIntegrationSuite) TestList() {
 		require.NoError(t, s.repo.Create(ctx, item))
 	}
 
-	items, err := s.repo.List(ctx, 10, 0)
+	items, err := s.repo.List(ctx, domain.ListQuery{Limit: 10})
 	require.NoError(t, err)
 	require.Len(t, items, 3)
 }
 
+// TestList_PagesAreDisjoint creates items that share created_at and name so
+// only the id tie-breaker orders them, and checks consecutive pages never
+// overlap.
+func (s *RepositoryIntegrationSuite) TestList_PagesAreDisjoint() {
+	t := s.T()
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for range 10 {
+		item := &domain.Item{ID: uuid.New(), Name: "same", CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, s.repo.Create(ctx, item))
+	}
+
+	for _, sort := range [][]sorting.Key{nil, {{Field: "name", Direction: sorting.Asc}}} {
+		seen := make(map[uuid.UUID]struct{})
+		for offset := int32(0); offset < 10; offset += 4 {
+			page, err := s.repo.List(ctx, domain.ListQuery{Limit: 4, Offset: offset, Sort: sort})
+			require.NoError(t, err)
+			for _, item := range page {
+				require.NotContains(t, seen, item.ID, "item %s on two pages", item.ID)
+				seen[item.ID] = struct{}{}
+			}
+		}
+		require.Len(t, seen, 10)
+	}
+}
+
+// TestCanceledContextStopsQueryServerSide cancels a request-scoped context
+// mid-query and asserts pgx cancels the statement on the server rather than
+// letting it run to completion.
+func (s *RepositoryIntegrationSuite) TestCanceledContextStopsQueryServerSide() {
+	t := s.T()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := s.db.WithContext(ctx).Exec("SELECT pg_sleep(30) /* cancel-probe */").Error
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 10*time.Second, "query was not canceled")
+
+	require.Eventually(t, func() bool {
+		var running int64
+		err := s.db.WithContext(context.Background()).
+			Raw("SELECT count(*) FROM pg_stat_activity WHERE state = 'active' AND query LIKE '%cancel-probe%' AND pid <> pg_backend_pid()").
+			Scan(&running).Error
+		return err == nil && running == 0
+	}, 5*time.Second, 100*time.Millisecond, "pg_sleep still running server-side")
+}
+
 func (s *RepositoryIntegrationSuite) runMigrations(cfg *config.Config) {
 	t := s.T()
 	t.Helper()
@@ -20,6 +20,7 @@ import (
 
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
 	"github.com/zercle/zercle-go-template/internal/features/example/repository"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
 // newTestDB builds a *gorm.DB backed by go-sqlmock so each test can assert
@@ -168,7 +169,7 @@ func TestRepository_List(t *testing.T) {
 				AddRow(id.String(), "listed", now, now),
 		)
 
-	items, err := repo.List(context.Background(), limit, offset)
+	items, err := repo.List(context.Background(), domain.ListQuery{Limit: limit, Offset: offset})
 	require.NoError(t, err)
 	require.Len(t, items, 1)
 	assert.Equal(t, id, items[0].ID)
@@ -188,7 +189,7 @@ func TestRepository_List_WithOffset(t *testing.T) {
 			sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}),
 		)
 
-	items, err := repo.List(context.Background(), limit, offset)
+	items, err := repo.List(context.Background(), domain.ListQuery{Limit: limit, Offset: offset})
 	require.NoError(t, err)
 	assert.Empty(t, items)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -202,9 +203,67 @@ func TestRepository_List_Error(t *testing.T) {
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnError(errors.New("query failed"))
 
-	items, err := repo.List(context.Background(), 10, 0)
+	items, err := repo.List(context.Background(), domain.ListQuery{Limit: 10})
 	assert.Error(t, err)
 	assert.Nil(t, items)
 	assert.Contains(t, err.Error(), "list items")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRepository_List_WithSort(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	repo := repository.NewRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT \* FROM "items" ORDER BY name ASC, id ASC LIMIT \$1`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}),
+		)
+
+	items, err := repo.List(context.Background(), domain.ListQuery{
+		Limit: 10,
+		Sort:  []sorting.Key{{Field: "name", Direction: sorting.Asc}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_List_InvalidSort(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	repo := repository.NewRepository(gormDB)
+
+	items, err := repo.List(context.Background(), domain.ListQuery{
+		Limit: 10,
+		Sort:  []sorting.Key{{Field: "updated_at; DROP TABLE items", Direction: sorting.Asc}},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+	assert.Nil(t, items)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Verify(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	repo := repository.NewRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT "items"\."id","items"\."name","items"\."created_at","items"\."updated_at" FROM "items" LIMIT \$1`).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}))
+
+	require.NoError(t, repo.Verify(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Verify_MissingColumn(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	repo := repository.NewRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT .* FROM "items" LIMIT \$1`).
+		WithArgs(0).
+		WillReturnError(errors.New(`column items.name does not exist`))
+
+	err := repo.Verify(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "items")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
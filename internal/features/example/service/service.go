@@ -6,19 +6,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
-	"time"
 	"unicode/utf8"
 
 	"github.com/google/uuid"
 
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/events"
+	"github.com/zercle/zercle-go-template/pkg/readthrough"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
+	"github.com/zercle/zercle-go-template/pkg/uuidgen"
 )
 
 const (
 	defaultPageSizeFallback int32 = 20
 	maxPageSizeFallback     int32 = 100
 	maxNameLengthFallback   int32 = 255
+	maxOffsetFallback       int32 = 10000
 )
 
 // Service implements the domain.Service inbound use-case port.
@@ -27,12 +33,61 @@ type Service struct {
 	defaultPageSize int32
 	maxPageSize     int32
 	maxNameLength   int32
+	maxOffset       int32
+	defaultSort     []sorting.Key
+	listCache       *readthrough.Cache[[]domain.Item]
+	clock           clock.Clock
+	newID           func() uuid.UUID
+	events          *events.Bus
+}
+
+// Option configures optional Service behaviour.
+type Option func(*Service)
+
+// WithListCache serves List through cache, so concurrent identical listings
+// collapse into one repository call and repeat within the cache TTL are
+// served from memory. Create invalidates it.
+func WithListCache(cache *readthrough.Cache[[]domain.Item]) Option {
+	return func(s *Service) { s.listCache = cache }
+}
+
+// WithMaxOffset rejects listings whose offset exceeds n with
+// domain.ErrOffsetTooLarge. Values below 1 keep the default of 10000.
+func WithMaxOffset(n int32) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.maxOffset = n
+		}
+	}
+}
+
+// WithDefaultSort orders listings that request no sort by keys, which must
+// already be validated against domain.SortableFields. Without it the
+// repository's newest-first order applies.
+func WithDefaultSort(keys []sorting.Key) Option {
+	return func(s *Service) { s.defaultSort = keys }
+}
+
+// WithClock stamps CreatedAt/UpdatedAt from clk instead of the system clock.
+func WithClock(clk clock.Clock) Option {
+	return func(s *Service) { s.clock = clk }
+}
+
+// WithEvents publishes a domain.ItemCreated on bus for every item Create
+// persists.
+func WithEvents(bus *events.Bus) Option {
+	return func(s *Service) { s.events = bus }
+}
+
+// WithIDGenerator assigns new item IDs from gen instead of uuidgen.New.
+func WithIDGenerator(gen func() uuid.UUID) Option {
+	return func(s *Service) { s.newID = gen }
 }
 
 // NewService returns a Service backed by the provided repository. The limit
 // arguments override the package fallback defaults; pass <= 0 to use the
 // built-in defaults (20/100/255).
-func NewService(repo domain.Repository, defaultPageSize, maxPageSize, maxNameLength int32) *Service {
+func NewService(repo domain.Repository, defaultPageSize, maxPageSize, maxNameLength int32, opts ...Option) *Service {
 	if defaultPageSize <= 0 {
 		defaultPageSize = defaultPageSizeFallback
 	}
@@ -42,24 +97,32 @@ func NewService(repo domain.Repository, defaultPageSize, maxPageSize, maxNameLen
 	if maxNameLength <= 0 {
 		maxNameLength = maxNameLengthFallback
 	}
-	return &Service{
+	s := &Service{
 		repo:            repo,
 		defaultPageSize: defaultPageSize,
 		maxPageSize:     maxPageSize,
 		maxNameLength:   maxNameLength,
+		maxOffset:       maxOffsetFallback,
+		clock:           clock.New(),
+		newID:           uuidgen.New,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// Create validates the name and persists a new item.
+// Create validates the name, persists a new item and, with WithEvents,
+// publishes domain.ItemCreated.
 func (s *Service) Create(ctx context.Context, name string) (*domain.Item, error) {
 	name = strings.TrimSpace(name)
 	if name == "" || utf8.RuneCountInString(name) > int(s.maxNameLength) {
 		return nil, domain.ErrInvalidName
 	}
 
-	now := time.Now().UTC()
+	now := s.clock.Now().UTC()
 	item := &domain.Item{
-		ID:        uuid.New(),
+		ID:        s.newID(),
 		Name:      name,
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -68,6 +131,16 @@ func (s *Service) Create(ctx context.Context, name string) (*domain.Item, error)
 	if err := s.repo.Create(ctx, item); err != nil {
 		return nil, fmt.Errorf("create item: %w", err)
 	}
+	// Invalidate here rather than from an ItemCreated subscriber: the bus is
+	// asynchronous, and the caller's next listing must include the item.
+	if s.listCache != nil {
+		s.listCache.Invalidate()
+	}
+	if s.events != nil {
+		// The item is already stored; a bus closed for shutdown only loses
+		// the notification, which events does not promise to deliver.
+		_ = s.events.Publish(ctx, domain.ItemCreated{Item: *item})
+	}
 
 	return item, nil
 }
@@ -89,22 +162,51 @@ func (s *Service) Get(ctx context.Context, id uuid.UUID) (*domain.Item, error) {
 }
 
 // List returns a paginated list of items. It enforces safe defaults so a
-// zero-value limit (e.g. no query parameter) never produces LIMIT 0.
-func (s *Service) List(ctx context.Context, limit, offset int32) ([]domain.Item, error) {
-	if limit <= 0 {
-		limit = s.defaultPageSize
+// zero-value limit (e.g. no query parameter) never produces LIMIT 0, and
+// returns domain.ErrOffsetTooLarge for an offset beyond the configured
+// maximum rather than running a query that skips that many rows.
+func (s *Service) List(ctx context.Context, q domain.ListQuery) ([]domain.Item, error) {
+	if q.Offset > s.maxOffset {
+		return nil, fmt.Errorf("%w: offset %d exceeds %d", domain.ErrOffsetTooLarge, q.Offset, s.maxOffset)
+	}
+	if q.Limit <= 0 {
+		q.Limit = s.defaultPageSize
+	}
+	if q.Limit > s.maxPageSize {
+		q.Limit = s.maxPageSize
 	}
-	if limit > s.maxPageSize {
-		limit = s.maxPageSize
+	if q.Offset < 0 {
+		q.Offset = 0
 	}
-	if offset < 0 {
-		offset = 0
+	if len(q.Sort) == 0 {
+		q.Sort = s.defaultSort
 	}
 
-	items, err := s.repo.List(ctx, limit, offset)
+	if s.listCache == nil {
+		items, err := s.repo.List(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("list items: %w", err)
+		}
+		return items, nil
+	}
+
+	items, err := s.listCache.Get(ctx, listCacheKey(q), func(ctx context.Context) ([]domain.Item, error) {
+		return s.repo.List(ctx, q)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("list items: %w", err)
 	}
 
-	return items, nil
+	// The cached slice is shared between callers; hand out a copy.
+	return slices.Clone(items), nil
+}
+
+// listCacheKey renders a normalized ListQuery as a cache key.
+func listCacheKey(q domain.ListQuery) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d:%d", q.Limit, q.Offset)
+	for _, k := range q.Sort {
+		fmt.Fprintf(&b, ":%s=%s", k.Field, k.Direction)
+	}
+	return b.String()
 }
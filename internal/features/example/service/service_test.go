@@ -7,7 +7,9 @@ package service_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -16,6 +18,10 @@ import (
 	"github.com/zercle/zercle-go-template/internal/features/example/domain"
 	"github.com/zercle/zercle-go-template/internal/features/example/repository/mock"
 	"github.com/zercle/zercle-go-template/internal/features/example/service"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/events"
+	"github.com/zercle/zercle-go-template/pkg/readthrough"
+	"github.com/zercle/zercle-go-template/pkg/sorting"
 )
 
 func TestService_Create_Happy(t *testing.T) {
@@ -32,11 +38,82 @@ func TestService_Create_Happy(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, item)
 	require.Equal(t, "stub", item.Name)
-	require.NotEqual(t, uuid.Nil, item.ID)
+	require.Equal(t, uuid.Version(7), item.ID.Version())
 	require.False(t, item.CreatedAt.IsZero())
 	require.False(t, item.UpdatedAt.IsZero())
 }
 
+func TestService_Create_StampsFromClock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().Create(ctx, matchItemName("stub")).Return(nil)
+
+	now := time.Date(2026, 5, 1, 7, 30, 0, 0, time.FixedZone("ICT", 7*60*60))
+	svc := service.NewService(repo, 0, 0, 0, service.WithClock(clock.NewFake(now)))
+	item, err := svc.Create(ctx, "stub")
+
+	require.NoError(t, err)
+	require.Equal(t, now.UTC(), item.CreatedAt)
+	require.Equal(t, now.UTC(), item.UpdatedAt)
+}
+
+func TestService_Create_IDFromGenerator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().Create(ctx, matchItemName("stub")).Return(nil)
+
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	svc := service.NewService(repo, 0, 0, 0, service.WithIDGenerator(func() uuid.UUID { return id }))
+	item, err := svc.Create(ctx, "stub")
+
+	require.NoError(t, err)
+	require.Equal(t, id, item.ID)
+}
+
+func TestService_Create_PublishesItemCreated(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().Create(ctx, matchItemName("stub")).Return(nil)
+
+	bus := events.New()
+	t.Cleanup(func() { _ = bus.Close(context.Background()) })
+	created := make(chan domain.ItemCreated, 1)
+	events.SubscribeTo(bus, func(_ context.Context, e domain.ItemCreated) { created <- e })
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithEvents(bus))
+	item, err := svc.Create(ctx, "stub")
+	require.NoError(t, err)
+
+	select {
+	case e := <-created:
+		require.Equal(t, *item, e.Item)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ItemCreated was not published")
+	}
+}
+
+func TestService_Create_RepositoryErrorPublishesNothing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().Create(ctx, matchItemName("stub")).Return(errors.New("boom"))
+
+	bus := events.New()
+	events.SubscribeTo(bus, func(context.Context, domain.ItemCreated) { t.Error("ItemCreated published for a failed create") })
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithEvents(bus))
+	_, err := svc.Create(ctx, "stub")
+	require.Error(t, err)
+	require.NoError(t, bus.Close(ctx))
+}
+
 func TestService_Create_EmptyName(t *testing.T) {
 	t.Parallel()
 
@@ -113,10 +190,10 @@ func TestService_List(t *testing.T) {
 	repo := mock.NewMockRepository(gomock.NewController(t))
 
 	expected := []domain.Item{{ID: uuid.New(), Name: "one"}}
-	repo.EXPECT().List(ctx, int32(10), int32(5)).Return(expected, nil)
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 10, Offset: 5}).Return(expected, nil)
 
 	svc := service.NewService(repo, 0, 0, 0)
-	items, err := svc.List(ctx, 10, 5)
+	items, err := svc.List(ctx, domain.ListQuery{Limit: 10, Offset: 5})
 
 	require.NoError(t, err)
 	require.Equal(t, expected, items)
@@ -129,10 +206,10 @@ func TestService_List_AppliesDefaultLimit(t *testing.T) {
 	repo := mock.NewMockRepository(gomock.NewController(t))
 
 	expected := []domain.Item{{ID: uuid.New(), Name: "default"}}
-	repo.EXPECT().List(ctx, int32(20), int32(5)).Return(expected, nil)
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 20, Offset: 5}).Return(expected, nil)
 
 	svc := service.NewService(repo, 0, 0, 0)
-	items, err := svc.List(ctx, 0, 5)
+	items, err := svc.List(ctx, domain.ListQuery{Limit: 0, Offset: 5})
 
 	require.NoError(t, err)
 	require.Equal(t, expected, items)
@@ -145,10 +222,10 @@ func TestService_List_ClampsOverMaxLimit(t *testing.T) {
 	repo := mock.NewMockRepository(gomock.NewController(t))
 
 	expected := []domain.Item{{ID: uuid.New(), Name: "clamped"}}
-	repo.EXPECT().List(ctx, int32(100), int32(0)).Return(expected, nil)
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 100, Offset: 0}).Return(expected, nil)
 
 	svc := service.NewService(repo, 0, 0, 0)
-	items, err := svc.List(ctx, 999, -5)
+	items, err := svc.List(ctx, domain.ListQuery{Limit: 999, Offset: -5})
 
 	require.NoError(t, err)
 	require.Equal(t, expected, items)
@@ -161,15 +238,148 @@ func TestService_List_RespectsConfiguredMaxPageSize(t *testing.T) {
 	repo := mock.NewMockRepository(gomock.NewController(t))
 
 	expected := []domain.Item{{ID: uuid.New(), Name: "clamped"}}
-	repo.EXPECT().List(ctx, int32(50), int32(0)).Return(expected, nil)
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 50, Offset: 0}).Return(expected, nil)
 
 	svc := service.NewService(repo, 10, 50, 255)
-	items, err := svc.List(ctx, 999, 0)
+	items, err := svc.List(ctx, domain.ListQuery{Limit: 999, Offset: 0})
 
 	require.NoError(t, err)
 	require.Equal(t, expected, items)
 }
 
+func TestService_List_RejectsOffsetBeyondMax(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithMaxOffset(500))
+	items, err := svc.List(ctx, domain.ListQuery{Offset: 1_000_000_000})
+
+	require.ErrorIs(t, err, domain.ErrOffsetTooLarge)
+	require.ErrorContains(t, err, "offset 1000000000 exceeds 500")
+	require.Nil(t, items)
+}
+
+func TestService_List_AllowsOffsetAtMax(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 20, Offset: 10000}).Return([]domain.Item{}, nil)
+
+	svc := service.NewService(repo, 0, 0, 0)
+	_, err := svc.List(ctx, domain.ListQuery{Offset: 10000})
+
+	require.NoError(t, err)
+}
+
+func TestService_List_AppliesDefaultSort(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+	byName := []sorting.Key{{Field: "name", Direction: sorting.Asc}}
+	byCreated := []sorting.Key{{Field: "created_at", Direction: sorting.Asc}}
+
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 20, Sort: byName}).Return([]domain.Item{}, nil)
+	repo.EXPECT().List(ctx, domain.ListQuery{Limit: 20, Sort: byCreated}).Return([]domain.Item{}, nil)
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithDefaultSort(byName))
+
+	_, err := svc.List(ctx, domain.ListQuery{})
+	require.NoError(t, err)
+
+	// An explicit sort replaces the default.
+	_, err = svc.List(ctx, domain.ListQuery{Sort: byCreated})
+	require.NoError(t, err)
+}
+
+func newListCache(t *testing.T) *readthrough.Cache[[]domain.Item] {
+	t.Helper()
+
+	cache, err := readthrough.New[[]domain.Item]("test", time.Minute)
+	require.NoError(t, err)
+	return cache
+}
+
+func TestService_List_CachedCollapsesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+
+	expected := []domain.Item{{ID: uuid.New(), Name: "hot"}}
+	repo.EXPECT().List(gomock.Any(), domain.ListQuery{Limit: 20}).
+		DoAndReturn(func(context.Context, domain.ListQuery) ([]domain.Item, error) {
+			time.Sleep(20 * time.Millisecond)
+			return expected, nil
+		}).
+		Times(1)
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithListCache(newListCache(t)))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for range 100 {
+		wg.Go(func() {
+			items, err := svc.List(ctx, domain.ListQuery{})
+			if err == nil && len(items) != 1 {
+				err = errors.New("unexpected items")
+			}
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestService_List_CacheKeyIncludesQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+
+	byName := domain.ListQuery{Limit: 20, Sort: []sorting.Key{{Field: "name", Direction: sorting.Asc}}}
+	repo.EXPECT().List(gomock.Any(), domain.ListQuery{Limit: 20}).Return(nil, nil).Times(1)
+	repo.EXPECT().List(gomock.Any(), byName).Return(nil, nil).Times(1)
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithListCache(newListCache(t)))
+	for range 2 {
+		_, err := svc.List(ctx, domain.ListQuery{})
+		require.NoError(t, err)
+		_, err = svc.List(ctx, byName)
+		require.NoError(t, err)
+	}
+}
+
+func TestService_Create_InvalidatesListCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mock.NewMockRepository(gomock.NewController(t))
+
+	repo.EXPECT().List(gomock.Any(), domain.ListQuery{Limit: 20}).Return(nil, nil).Times(2)
+	repo.EXPECT().Create(ctx, matchItemName("fresh")).Return(nil)
+
+	svc := service.NewService(repo, 0, 0, 0, service.WithListCache(newListCache(t)))
+
+	_, err := svc.List(ctx, domain.ListQuery{})
+	require.NoError(t, err)
+	_, err = svc.List(ctx, domain.ListQuery{})
+	require.NoError(t, err)
+
+	_, err = svc.Create(ctx, "fresh")
+	require.NoError(t, err)
+
+	_, err = svc.List(ctx, domain.ListQuery{})
+	require.NoError(t, err)
+}
+
 func TestService_Create_RepositoryError(t *testing.T) {
 	t.Parallel()
 
@@ -15,12 +15,23 @@ import (
 	"github.com/zercle/zercle-go-template/internal/config"
 )
 
+// maxConnectRetryDelay caps the exponential backoff between connection
+// attempts.
+const maxConnectRetryDelay = 30 * time.Second
+
+// connectFunc opens and pings a database once.
+type connectFunc func(ctx context.Context) (*gorm.DB, error)
+
 // NewDB builds a configured *gorm.DB from the application config. It derives
 // a DSN from cfg.DBConnString(), augments it with connect_timeout, opens the
 // GORM connection, applies pool tuning via the underlying *sql.DB, and pings
 // the database before returning. The caller is responsible for closing the
 // underlying *sql.DB obtained via (*gorm.DB).DB().
 //
+// A failed open or ping is retried up to cfg.DB.ConnectMaxAttempts times with
+// exponential backoff starting at cfg.DB.ConnectRetryDelay, so the process
+// survives a database that is still starting (e.g. under docker compose).
+//
 // Schema is owned by golang-migrate; AutoMigrate is never invoked here.
 func NewDB(ctx context.Context, cfg *config.Config, log *zerolog.Logger) (*gorm.DB, error) {
 	if cfg == nil {
@@ -36,6 +47,57 @@ func NewDB(ctx context.Context, cfg *config.Config, log *zerolog.Logger) (*gorm.
 		return nil, fmt.Errorf("build dsn: %w", err)
 	}
 
+	connect := func(ctx context.Context) (*gorm.DB, error) {
+		return open(ctx, cfg, log, dsn)
+	}
+	return connectWithRetry(ctx, connect, cfg.DB.ConnectMaxAttempts, cfg.DB.ConnectRetryDelay, log)
+}
+
+// connectWithRetry calls connect until it succeeds, attempts is exhausted, or
+// ctx is done. The wait doubles after each failure, starting at baseDelay and
+// capped at maxConnectRetryDelay. attempts below one are treated as one.
+func connectWithRetry(ctx context.Context, connect connectFunc, attempts int, baseDelay time.Duration, log *zerolog.Logger) (*gorm.DB, error) {
+	attempts = max(attempts, 1)
+	delay := baseDelay
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		gormDB, err := connect(ctx)
+		if err == nil {
+			if attempt > 1 {
+				log.Info().Int("attempt", attempt).Msg("database connection established")
+			}
+			return gormDB, nil
+		}
+		lastErr = err
+
+		if attempt == attempts {
+			break
+		}
+
+		log.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Int("max_attempts", attempts).
+			Dur("retry_in", delay).
+			Msg("database connection failed, retrying")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("connect db: %w (last error: %w)", ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxConnectRetryDelay)
+	}
+
+	return nil, fmt.Errorf("connect db after %d attempts: %w", attempts, lastErr)
+}
+
+// open opens the GORM connection for dsn, applies pool tuning, and pings the
+// database once.
+func open(ctx context.Context, cfg *config.Config, log *zerolog.Logger, dsn string) (*gorm.DB, error) {
 	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger:                 newGORMLogger(log, cfg),
 		SkipDefaultTransaction: true,
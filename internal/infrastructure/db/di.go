@@ -7,14 +7,18 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/samber/do/v2"
+	"gorm.io/gorm"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db/migrations"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
 )
 
-// Register provides *gorm.DB and registers the PostgreSQL readiness checker.
-// The ctx drives the initial DB construction so startup cancellation and
-// connect timeouts propagate.
+// Register provides *gorm.DB and *SchemaChecks and registers the PostgreSQL
+// readiness checker, plus the schema readiness checker when DB_VERIFY_SCHEMA
+// is enabled. The ctx drives the initial DB construction so startup
+// cancellation and connect timeouts propagate. Startup fails when the schema
+// is behind the migrations embedded in this binary.
 func Register(ctx context.Context, c do.Injector) error {
 	cfg := do.MustInvoke[*config.Config](c)
 
@@ -27,6 +31,13 @@ func Register(ctx context.Context, c do.Injector) error {
 	if err != nil {
 		return err
 	}
+
+	if err := checkEmbeddedMigrations(ctx, db); err != nil {
+		if sqlDB, dbErr := db.DB(); dbErr == nil {
+			_ = sqlDB.Close()
+		}
+		return err
+	}
 	do.ProvideValue(c, db)
 	// NewShutdowner and the Shutdowner struct live in shutdowner.go (same
 	// package); they adapt *gorm.DB to do's ShutdownerWithContextAndError so
@@ -39,5 +50,24 @@ func Register(ctx context.Context, c do.Injector) error {
 	}
 	registry.AddReadiness(gormChecker{db: db})
 
+	schemaChecks := NewSchemaChecks()
+	do.ProvideValue(c, schemaChecks)
+	if cfg.DB.VerifySchema {
+		registry.AddReadiness(schemaChecks)
+	}
+
+	return nil
+}
+
+// checkEmbeddedMigrations runs CheckMigrations against the latest embedded
+// migration version.
+func checkEmbeddedMigrations(ctx context.Context, db *gorm.DB) error {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return fmt.Errorf("resolve expected schema version: %w", err)
+	}
+	if err := CheckMigrations(ctx, db, latest); err != nil {
+		return fmt.Errorf("verify migrations: %w", err)
+	}
 	return nil
 }
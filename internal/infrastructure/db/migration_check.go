@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// migrationsTable is golang-migrate's default version table.
+const migrationsTable = "schema_migrations"
+
+// Startup migration check errors.
+var (
+	ErrMigrationsPending = errors.New("database migrations are not fully applied")
+	ErrMigrationsDirty   = errors.New("database migration state is dirty")
+)
+
+// migrationState mirrors a schema_migrations row.
+type migrationState struct {
+	Version int64
+	Dirty   bool
+}
+
+// CheckMigrations verifies the database schema is at least at version
+// expected and not left dirty by a failed migration. A database ahead of the
+// binary is accepted so an application rollback does not require a schema
+// rollback.
+func CheckMigrations(ctx context.Context, db *gorm.DB, expected uint) error {
+	var exists bool
+	if err := db.WithContext(ctx).
+		Raw("SELECT to_regclass(?) IS NOT NULL", migrationsTable).
+		Scan(&exists).Error; err != nil {
+		return fmt.Errorf("check migrations table: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s table is missing, run `task migrate-up`", ErrMigrationsPending, migrationsTable)
+	}
+
+	var state migrationState
+	res := db.WithContext(ctx).Raw("SELECT version, dirty FROM " + migrationsTable + " LIMIT 1").Scan(&state)
+	if res.Error != nil {
+		return fmt.Errorf("read migration version: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: no version recorded, run `task migrate-up`", ErrMigrationsPending)
+	}
+	if state.Dirty {
+		return fmt.Errorf("%w at version %d, fix the schema and run `migrate force`", ErrMigrationsDirty, state.Version)
+	}
+	if state.Version < 0 || uint64(state.Version) < uint64(expected) {
+		return fmt.Errorf("%w: database is at version %d, binary expects %d, run `task migrate-up`", ErrMigrationsPending, state.Version, expected)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+//go:build unit
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db/migrations"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func expectMigrationsTable(mock sqlmock.Sqlmock, exists bool) {
+	mock.ExpectQuery(`SELECT to_regclass\(\$1\) IS NOT NULL`).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(exists))
+}
+
+func TestCheckMigrations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "up to date",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(3, false))
+			},
+		},
+		{
+			name: "ahead of binary",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(4, false))
+			},
+		},
+		{
+			name: "behind binary",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+			},
+			wantErr: db.ErrMigrationsPending,
+		},
+		{
+			name: "dirty",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(3, true))
+			},
+			wantErr: db.ErrMigrationsDirty,
+		},
+		{
+			name: "no version row",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+			},
+			wantErr: db.ErrMigrationsPending,
+		},
+		{
+			name: "missing table",
+			setup: func(mock sqlmock.Sqlmock) {
+				expectMigrationsTable(mock, false)
+			},
+			wantErr: db.ErrMigrationsPending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gormDB, mock := newMockDB(t)
+			tc.setup(mock)
+
+			err := db.CheckMigrations(context.Background(), gormDB, 3)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCheckMigrations_QueryError(t *testing.T) {
+	t.Parallel()
+
+	gormDB, mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT to_regclass`).WillReturnError(errors.New("connection reset"))
+
+	err := db.CheckMigrations(context.Background(), gormDB, 3)
+	require.ErrorContains(t, err, "connection reset")
+}
+
+func TestMigrationsLatest(t *testing.T) {
+	t.Parallel()
+
+	latest, err := migrations.Latest()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, latest, uint(3))
+}
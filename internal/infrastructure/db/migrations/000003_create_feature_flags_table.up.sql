@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key TEXT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent SMALLINT NOT NULL DEFAULT 100 CHECK (rollout_percent BETWEEN 0 AND 100),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -2,7 +2,13 @@
 // cmd/migrate via the golang-migrate iofs driver.
 package migrations
 
-import "embed"
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
 
 // FS holds all SQL migration files in this directory. It is used by
 // cmd/migrate so the resulting binary is self-contained and does not rely on
@@ -10,3 +16,29 @@ import "embed"
 //
 //go:embed *.sql
 var FS embed.FS
+
+// Latest returns the highest migration version embedded in FS, i.e. the
+// schema version this binary expects the database to be at.
+func Latest() (uint, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("read embedded migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse migration version from %q: %w", entry.Name(), err)
+		}
+		latest = max(latest, uint(version))
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no embedded migrations found")
+	}
+	return latest, nil
+}
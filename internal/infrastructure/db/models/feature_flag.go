@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// FeatureFlag is the GORM persistence model for the "feature_flags" table.
+//
+// Schema is owned by golang-migrate; AutoMigrate is never used.
+type FeatureFlag struct {
+	Key            string    `gorm:"type:text;primaryKey"`
+	Enabled        bool      `gorm:"not null"`
+	RolloutPercent int16     `gorm:"type:smallint;not null"`
+	UpdatedAt      time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName returns the database table name for the FeatureFlag model.
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
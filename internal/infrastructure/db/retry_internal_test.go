@@ -0,0 +1,84 @@
+//go:build unit
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeConnector fails the first failures calls and then returns db.
+type fakeConnector struct {
+	failures int
+	calls    int
+	db       *gorm.DB
+}
+
+func (f *fakeConnector) connect(context.Context) (*gorm.DB, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("connection refused")
+	}
+	return f.db, nil
+}
+
+func TestConnectWithRetry_SucceedsAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	nop := zerolog.Nop()
+	want := &gorm.DB{}
+	fake := &fakeConnector{failures: 2, db: want}
+
+	got, err := connectWithRetry(context.Background(), fake.connect, 5, time.Millisecond, &nop)
+
+	require.NoError(t, err)
+	require.Same(t, want, got)
+	require.Equal(t, 3, fake.calls)
+}
+
+func TestConnectWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	nop := zerolog.Nop()
+	fake := &fakeConnector{failures: 10}
+
+	got, err := connectWithRetry(context.Background(), fake.connect, 3, time.Millisecond, &nop)
+
+	require.Error(t, err)
+	require.Nil(t, got)
+	require.Contains(t, err.Error(), "after 3 attempts")
+	require.Contains(t, err.Error(), "connection refused")
+	require.Equal(t, 3, fake.calls)
+}
+
+func TestConnectWithRetry_ZeroAttemptsTriesOnce(t *testing.T) {
+	t.Parallel()
+
+	nop := zerolog.Nop()
+	fake := &fakeConnector{failures: 10}
+
+	_, err := connectWithRetry(context.Background(), fake.connect, 0, time.Millisecond, &nop)
+
+	require.Error(t, err)
+	require.Equal(t, 1, fake.calls)
+}
+
+func TestConnectWithRetry_StopsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	nop := zerolog.Nop()
+	fake := &fakeConnector{failures: 10}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := connectWithRetry(ctx, fake.connect, 5, time.Hour, &nop)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, fake.calls)
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/samber/do/v2"
+	"gorm.io/gorm"
+
+	"github.com/zercle/zercle-go-template/internal/config"
+)
+
+// ErrSchemaMismatch is returned when a domain's queries do not match the live
+// database schema.
+var ErrSchemaMismatch = errors.New("database schema does not match the application")
+
+// SchemaCheck verifies that one domain's queries run against the live schema.
+type SchemaCheck func(ctx context.Context) error
+
+// SchemaChecks collects per-domain schema checks. Features add theirs during
+// DI registration; the composition root runs them once at startup and the
+// readiness probe reruns them so /readyz names a broken domain.
+type SchemaChecks struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]SchemaCheck
+}
+
+// NewSchemaChecks returns an empty SchemaChecks.
+func NewSchemaChecks() *SchemaChecks {
+	return &SchemaChecks{checks: make(map[string]SchemaCheck)}
+}
+
+// Add registers check under domain. Adding the same domain again replaces
+// its check.
+func (s *SchemaChecks) Add(domain string, check SchemaCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.checks[domain]; !ok {
+		s.names = append(s.names, domain)
+	}
+	s.checks[domain] = check
+}
+
+// Verify runs every check in registration order and returns an error
+// wrapping ErrSchemaMismatch that names each failing domain.
+func (s *SchemaChecks) Verify(ctx context.Context) error {
+	s.mu.RLock()
+	names := append([]string(nil), s.names...)
+	checks := make([]SchemaCheck, len(names))
+	for i, name := range names {
+		checks[i] = s.checks[name]
+	}
+	s.mu.RUnlock()
+
+	var errs []error
+	for i, check := range checks {
+		if err := check(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", names[i], err))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrSchemaMismatch, errors.Join(errs...))
+}
+
+// Name returns the dependency name reported in health output.
+func (*SchemaChecks) Name() string {
+	return "schema"
+}
+
+// Check implements telemetry.Checker by running Verify.
+func (s *SchemaChecks) Check(ctx context.Context) error {
+	return s.Verify(ctx)
+}
+
+// VerifyColumns selects every column of the model behind dest (a pointer to
+// a slice of models) by name with LIMIT 0. The query returns no rows, so it
+// is cheap, but it fails when a column the model maps is missing from the
+// table or the table itself is missing.
+func VerifyColumns(ctx context.Context, db *gorm.DB, dest any) error {
+	err := db.WithContext(ctx).
+		Session(&gorm.Session{QueryFields: true}).
+		Limit(0).
+		Find(dest).
+		Error
+	if err != nil {
+		return fmt.Errorf("verify columns: %w", err)
+	}
+	return nil
+}
+
+// AddSchemaCheck registers check under domain with the container's
+// *SchemaChecks. It is a no-op when schema verification is not wired (e.g. in
+// tests that register a feature on its own).
+func AddSchemaCheck(c do.Injector, domain string, check SchemaCheck) error {
+	checks, err := do.Invoke[*SchemaChecks](c)
+	if errors.Is(err, do.ErrServiceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("resolve schema checks: %w", err)
+	}
+	checks.Add(domain, check)
+	return nil
+}
+
+// VerifySchema runs the container's schema checks when DB_VERIFY_SCHEMA is
+// enabled. Call it after every feature has registered.
+func VerifySchema(ctx context.Context, c do.Injector) error {
+	cfg, err := do.Invoke[*config.Config](c)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+	if !cfg.DB.VerifySchema {
+		return nil
+	}
+	checks, err := do.Invoke[*SchemaChecks](c)
+	if err != nil {
+		return fmt.Errorf("resolve schema checks: %w", err)
+	}
+	return checks.Verify(ctx)
+}
@@ -0,0 +1,63 @@
+//go:build unit
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db/models"
+)
+
+func TestSchemaChecks_VerifyNamesFailingDomains(t *testing.T) {
+	t.Parallel()
+
+	checks := db.NewSchemaChecks()
+	checks.Add("items", func(context.Context) error { return nil })
+	checks.Add("orders", func(context.Context) error { return errors.New("column orders.total does not exist") })
+	checks.Add("users", func(context.Context) error { return errors.New(`relation "users" does not exist`) })
+
+	err := checks.Verify(context.Background())
+
+	require.ErrorIs(t, err, db.ErrSchemaMismatch)
+	assert.Contains(t, err.Error(), "orders: column orders.total does not exist")
+	assert.Contains(t, err.Error(), `users: relation "users" does not exist`)
+	assert.NotContains(t, err.Error(), "items")
+	assert.Equal(t, err, checks.Check(context.Background()))
+}
+
+func TestSchemaChecks_VerifyEmpty(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, db.NewSchemaChecks().Verify(context.Background()))
+}
+
+func TestSchemaChecks_AddReplaces(t *testing.T) {
+	t.Parallel()
+
+	checks := db.NewSchemaChecks()
+	checks.Add("items", func(context.Context) error { return errors.New("stale") })
+	checks.Add("items", func(context.Context) error { return nil })
+
+	require.NoError(t, checks.Verify(context.Background()))
+}
+
+func TestVerifyColumns(t *testing.T) {
+	t.Parallel()
+
+	gormDB, mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT "feature_flags"\."key",.* FROM "feature_flags" LIMIT \$1`).
+		WithArgs(0).
+		WillReturnError(errors.New(`column feature_flags.rollout_percent does not exist`))
+
+	err := db.VerifyColumns(context.Background(), gormDB, &[]models.FeatureFlag{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rollout_percent")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
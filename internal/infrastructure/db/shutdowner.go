@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"sync"
 
@@ -28,8 +29,9 @@ func NewShutdowner(db *gorm.DB) *Shutdowner {
 	return &Shutdowner{db: db}
 }
 
-// Shutdown implements do.ShutdownerWithContextAndError.
-func (s *Shutdowner) Shutdown(context.Context) error {
+// Shutdown implements do.ShutdownerWithContextAndError. It gives up waiting
+// on the pool when ctx ends; see closePool.
+func (s *Shutdowner) Shutdown(ctx context.Context) error {
 	s.once.Do(func() {
 		if s.db == nil {
 			return
@@ -39,7 +41,33 @@ func (s *Shutdowner) Shutdown(context.Context) error {
 			s.err = fmt.Errorf("gorm db sql handle unavailable: %w", err)
 			return
 		}
-		s.err = sqlDB.Close()
+		s.err = closePool(ctx, sqlDB)
 	})
 	return s.err
 }
+
+// pool is the part of *sql.DB that closePool needs.
+type pool interface {
+	Close() error
+	Stats() sql.DBStats
+}
+
+// closePool closes p, waiting at most until ctx ends. database/sql's Close
+// waits for queries already running on the server, so a stuck query would
+// otherwise hold shutdown open forever. On timeout the close keeps running
+// in the background and the returned error wraps ctx's error with the number
+// of connections still in use.
+func closePool(ctx context.Context, p pool) error {
+	done := make(chan error, 1)
+	go func() { done <- p.Close() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("close db pool: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close db pool: %d connections still in use: %w", p.Stats().InUse, ctx.Err())
+	}
+}
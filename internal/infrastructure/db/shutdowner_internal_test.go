@@ -0,0 +1,52 @@
+//go:build unit
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakePool blocks Close until release is closed, then returns err.
+type fakePool struct {
+	release chan struct{}
+	err     error
+	inUse   int
+}
+
+func (p *fakePool) Close() error {
+	<-p.release
+	return p.err
+}
+
+func (p *fakePool) Stats() sql.DBStats { return sql.DBStats{InUse: p.inUse} }
+
+func TestClosePool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the close error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &fakePool{release: make(chan struct{}), err: errors.New("boom")}
+		close(p.release)
+		require.ErrorContains(t, closePool(t.Context(), p), "boom")
+	})
+
+	t.Run("stops waiting at the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		p := &fakePool{release: make(chan struct{}), inUse: 3}
+		t.Cleanup(func() { close(p.release) })
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		err := closePool(ctx, p)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "3 connections still in use")
+	})
+}
@@ -0,0 +1,147 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gorm.io/gorm"
+
+	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+// defaultCacheTTL is used when FEATURE_FLAGS_CACHE_TTL is unset.
+const defaultCacheTTL = 30 * time.Second
+
+// Register provides the feature flag *Store, the *featureflag.Cached view
+// of it, and the featureflag.Provider used per request (the cache, falling
+// back to the static flags from config). It installs the evaluation
+// middleware on the public echo and, when the admin listener is enabled,
+// mounts /admin/feature-flags on it. Health, version, and diagnostics
+// requests skip flag evaluation so they never wait on the database. It
+// depends on config, logger, *gorm.DB and the shared servers already being
+// registered.
+func Register(c do.Injector) error {
+	sharederrors.RegisterSentinel(featureflag.ErrInvalidFlag, sharederrors.ErrInvalidInput)
+
+	do.Provide(c, func(i do.Injector) (*Store, error) {
+		gormDB, err := do.Invoke[*gorm.DB](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve gorm db: %w", err)
+		}
+		clk, err := do.Invoke[clock.Clock](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve clock: %w", err)
+		}
+		return NewStore(gormDB, clk), nil
+	})
+
+	if err := db.AddSchemaCheck(c, "feature_flags", func(ctx context.Context) error {
+		store, err := do.Invoke[*Store](c)
+		if err != nil {
+			return fmt.Errorf("resolve feature flag store: %w", err)
+		}
+		return store.Verify(ctx)
+	}); err != nil {
+		return err
+	}
+
+	do.Provide(c, func(i do.Injector) (*featureflag.Cached, error) {
+		cfg, err := do.Invoke[*config.Config](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve config: %w", err)
+		}
+		store, err := do.Invoke[*Store](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve feature flag store: %w", err)
+		}
+		ttl := cfg.FeatureFlags.CacheTTL
+		if ttl <= 0 {
+			ttl = defaultCacheTTL
+		}
+		clk, err := do.Invoke[clock.Clock](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve clock: %w", err)
+		}
+		return featureflag.NewCached(store, ttl, clk.Now), nil
+	})
+
+	do.Provide(c, func(i do.Injector) (featureflag.Provider, error) {
+		cfg, err := do.Invoke[*config.Config](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve config: %w", err)
+		}
+		cached, err := do.Invoke[*featureflag.Cached](i)
+		if err != nil {
+			return nil, fmt.Errorf("resolve feature flag cache: %w", err)
+		}
+		return featureflag.Fallback(cached, staticFlags(cfg.FeatureFlags.Static)), nil
+	})
+
+	provider, err := do.Invoke[featureflag.Provider](c)
+	if err != nil {
+		return fmt.Errorf("resolve feature flag provider: %w", err)
+	}
+	logger, err := do.Invoke[*zerolog.Logger](c)
+	if err != nil {
+		return fmt.Errorf("resolve logger: %w", err)
+	}
+	e, err := do.Invoke[*echo.Echo](c)
+	if err != nil {
+		return fmt.Errorf("resolve echo: %w", err)
+	}
+	e.Use(middleware.FeatureFlags(middleware.FeatureFlagsConfig{
+		Provider: provider,
+		Subject:  middleware.ClientIPSubject,
+		Logger:   logger,
+		Skip:     server.IsOperational,
+	}))
+
+	return registerAdmin(c)
+}
+
+// registerAdmin mounts the admin routes when the admin listener exists. The
+// endpoints are unauthenticated, so they are never put on the public echo.
+func registerAdmin(c do.Injector) error {
+	admin, err := do.InvokeNamed[*echo.Echo](c, server.AdminHTTPName)
+	if errors.Is(err, do.ErrServiceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("resolve admin echo: %w", err)
+	}
+
+	store, err := do.Invoke[*Store](c)
+	if err != nil {
+		return fmt.Errorf("resolve feature flag store: %w", err)
+	}
+	cached, err := do.Invoke[*featureflag.Cached](c)
+	if err != nil {
+		return fmt.Errorf("resolve feature flag cache: %w", err)
+	}
+	NewHandler(store, cached).Register(admin.Group("/admin"))
+
+	return nil
+}
+
+// staticFlags builds the fallback provider from the configured keys.
+func staticFlags(keys []string) *featureflag.Static {
+	flags := make([]featureflag.Flag, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		flags = append(flags, featureflag.Flag{Key: key, Enabled: true, RolloutPercent: 100})
+	}
+	return featureflag.NewStatic(flags...)
+}
@@ -0,0 +1,97 @@
+package featureflags
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+// flagResponse is the JSON shape of a feature flag.
+type flagResponse struct {
+	Key            string    `json:"key"`
+	Enabled        bool      `json:"enabled"`
+	RolloutPercent int       `json:"rollout_percent"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// putFlagRequest is the body accepted by PUT /admin/feature-flags/:key.
+// RolloutPercent defaults to 100 when omitted.
+type putFlagRequest struct {
+	Enabled        bool `json:"enabled"`
+	RolloutPercent *int `json:"rollout_percent"`
+}
+
+// Handler serves the feature flag admin endpoints.
+type Handler struct {
+	store *Store
+	cache *featureflag.Cached
+}
+
+// NewHandler returns a Handler. cache is invalidated after every write so
+// the change is visible to this instance immediately; other instances pick
+// it up when their cache expires.
+func NewHandler(store *Store, cache *featureflag.Cached) *Handler {
+	return &Handler{store: store, cache: cache}
+}
+
+// Register mounts the admin routes on g.
+func (h *Handler) Register(g *echo.Group) {
+	g.GET("/feature-flags", h.List)
+	g.PUT("/feature-flags/:key", h.Put)
+}
+
+// List handles GET /feature-flags.
+//
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func (h *Handler) List(c *echo.Context) error {
+	flags, err := h.store.List(c.Request().Context())
+	if err != nil {
+		status, body := sharederrors.HTTPError(err)
+		return c.JSON(status, body)
+	}
+
+	resp := make([]flagResponse, 0, len(flags))
+	for _, f := range flags {
+		resp = append(resp, toResponse(f))
+	}
+	return c.JSON(http.StatusOK, map[string]any{"feature_flags": resp})
+}
+
+// Put handles PUT /feature-flags/:key. Unknown body fields are rejected.
+//
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func (h *Handler) Put(c *echo.Context) error {
+	var req putFlagRequest
+	if err := request.BindStrict(c, &req); err != nil {
+		status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+		return c.JSON(status, body)
+	}
+
+	flag := featureflag.Flag{Key: c.Param("key"), Enabled: req.Enabled, RolloutPercent: 100}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+
+	stored, err := h.store.Upsert(c.Request().Context(), flag)
+	if err != nil {
+		status, body := sharederrors.HTTPError(err)
+		return c.JSON(status, body)
+	}
+	h.cache.Invalidate()
+
+	return c.JSON(http.StatusOK, toResponse(stored))
+}
+
+func toResponse(f featureflag.Flag) flagResponse {
+	return flagResponse{
+		Key:            f.Key,
+		Enabled:        f.Enabled,
+		RolloutPercent: f.RolloutPercent,
+		UpdatedAt:      f.UpdatedAt,
+	}
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+package featureflags_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/infrastructure/featureflags"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+var registerSentinelsOnce sync.Once
+
+func newAdminEcho(t *testing.T) (*echo.Echo, sqlmock.Sqlmock, *featureflag.Cached) {
+	t.Helper()
+
+	registerSentinelsOnce.Do(func() {
+		sharederrors.RegisterSentinel(featureflag.ErrInvalidFlag, sharederrors.ErrInvalidInput)
+	})
+
+	gormDB, mock := newTestDB(t)
+	store := featureflags.NewStore(gormDB, nil)
+	cached := featureflag.NewCached(store, time.Hour, nil)
+
+	e := echo.New()
+	featureflags.NewHandler(store, cached).Register(e.Group("/admin"))
+	return e, mock, cached
+}
+
+func TestHandler_Put_InvalidatesCache(t *testing.T) {
+	e, mock, cached := newAdminEcho(t)
+	now := time.Now().UTC()
+	rows := []string{"key", "enabled", "rollout_percent", "updated_at"}
+
+	mock.ExpectQuery(`SELECT \* FROM "feature_flags"`).
+		WillReturnRows(sqlmock.NewRows(rows).AddRow("f", false, 100, now))
+	flags, err := cached.Flags(context.Background())
+	require.NoError(t, err)
+	require.False(t, flags["f"].Enabled)
+
+	mock.ExpectExec(`INSERT INTO "feature_flags"`).
+		WithArgs("f", true, int16(100), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "feature_flags"`).
+		WillReturnRows(sqlmock.NewRows(rows).AddRow("f", true, 100, now))
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/feature-flags/f", strings.NewReader(`{"enabled":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"rollout_percent":100`)
+
+	flags, err = cached.Flags(context.Background())
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_Put_RejectsInvalidRollout(t *testing.T) {
+	e, mock, _ := newAdminEcho(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/feature-flags/f", strings.NewReader(`{"enabled":true,"rollout_percent":101}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "INVALID_INPUT")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,108 @@
+// Package featureflags persists feature flags in PostgreSQL, exposes them
+// as a cached featureflag.Provider, and serves the admin endpoints that
+// toggle them at runtime.
+package featureflags
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db"
+	"github.com/zercle/zercle-go-template/internal/infrastructure/db/models"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+// Store is a GORM-backed featureflag.Provider over the feature_flags table.
+type Store struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+// NewStore returns a Store backed by db that stamps UpdatedAt from clk. A nil
+// clk uses the system clock.
+func NewStore(db *gorm.DB, clk clock.Clock) *Store {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Store{db: db, clock: clk}
+}
+
+// Verify checks that the feature_flags table has every column the model
+// maps. It is registered as the feature_flags startup schema check.
+func (s *Store) Verify(ctx context.Context) error {
+	if err := db.VerifyColumns(ctx, s.db, &[]models.FeatureFlag{}); err != nil {
+		return fmt.Errorf("feature_flags: %w", err)
+	}
+	return nil
+}
+
+// List returns every flag ordered by key.
+func (s *Store) List(ctx context.Context) ([]featureflag.Flag, error) {
+	var ms []models.FeatureFlag
+	if err := s.db.WithContext(ctx).Order("key ASC").Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("list feature flags: %w", err)
+	}
+
+	flags := make([]featureflag.Flag, 0, len(ms))
+	for i := range ms {
+		flags = append(flags, mapModelToFlag(&ms[i]))
+	}
+	return flags, nil
+}
+
+// Flags implements featureflag.Provider.
+func (s *Store) Flags(ctx context.Context) (map[string]featureflag.Flag, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]featureflag.Flag, len(list))
+	for _, f := range list {
+		flags[f.Key] = f
+	}
+	return flags, nil
+}
+
+// Upsert validates f and creates or replaces the flag with the same key,
+// stamping UpdatedAt. It returns the stored flag.
+func (s *Store) Upsert(ctx context.Context, f featureflag.Flag) (featureflag.Flag, error) {
+	if err := f.Validate(); err != nil {
+		return featureflag.Flag{}, fmt.Errorf("upsert feature flag: %w", err)
+	}
+
+	f.UpdatedAt = s.clock.Now().UTC()
+	m := mapFlagToModel(f)
+	err := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "rollout_percent", "updated_at"}),
+		}).
+		Create(&m).Error
+	if err != nil {
+		return featureflag.Flag{}, fmt.Errorf("upsert feature flag: %w", err)
+	}
+	return f, nil
+}
+
+func mapModelToFlag(m *models.FeatureFlag) featureflag.Flag {
+	return featureflag.Flag{
+		Key:            m.Key,
+		Enabled:        m.Enabled,
+		RolloutPercent: int(m.RolloutPercent),
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func mapFlagToModel(f featureflag.Flag) models.FeatureFlag {
+	return models.FeatureFlag{
+		Key:            f.Key,
+		Enabled:        f.Enabled,
+		RolloutPercent: int16(f.RolloutPercent), //nolint:gosec // Validate bounds RolloutPercent to 0-100.
+		UpdatedAt:      f.UpdatedAt,
+	}
+}
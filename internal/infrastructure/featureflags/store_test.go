@@ -0,0 +1,79 @@
+//go:build unit
+
+package featureflags_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zercle/zercle-go-template/internal/infrastructure/featureflags"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+func newTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestStore_Flags(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	store := featureflags.NewStore(gormDB, nil)
+	now := time.Now().UTC()
+
+	mock.ExpectQuery(`SELECT \* FROM "feature_flags" ORDER BY key ASC`).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"key", "enabled", "rollout_percent", "updated_at"}).
+				AddRow("a", true, 100, now).
+				AddRow("b", false, 25, now),
+		)
+
+	flags, err := store.Flags(context.Background())
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+	assert.Equal(t, featureflag.Flag{Key: "b", Enabled: false, RolloutPercent: 25, UpdatedAt: now}, flags["b"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_Upsert(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := featureflags.NewStore(gormDB, clock.NewFake(now))
+
+	mock.ExpectExec(`INSERT INTO "feature_flags" .* ON CONFLICT \("key"\) DO UPDATE SET "enabled"="excluded"."enabled","rollout_percent"="excluded"."rollout_percent","updated_at"="excluded"."updated_at"`).
+		WithArgs("a", true, int16(40), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	stored, err := store.Upsert(context.Background(), featureflag.Flag{Key: "a", Enabled: true, RolloutPercent: 40})
+	require.NoError(t, err)
+	assert.Equal(t, now, stored.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_Upsert_RejectsInvalidFlag(t *testing.T) {
+	gormDB, mock := newTestDB(t)
+	store := featureflags.NewStore(gormDB, nil)
+
+	_, err := store.Upsert(context.Background(), featureflag.Flag{Key: "a", RolloutPercent: 150})
+	require.ErrorIs(t, err, featureflag.ErrInvalidFlag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
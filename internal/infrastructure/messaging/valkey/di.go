@@ -13,12 +13,17 @@ import (
 
 // Register provides valkeygo.Client and registers the Valkey readiness
 // checker. The ctx is used to drive the initial client construction so
-// startup cancellation/timeouts propagate.
+// startup cancellation/timeouts propagate. With VALKEY_ENABLED=false it
+// provides nothing; consumers resolve the client as optional and treat
+// do.ErrServiceNotFound as "not configured".
 func Register(ctx context.Context, c do.Injector) error {
 	cfg, err := do.Invoke[*config.Config](c)
 	if err != nil {
 		return fmt.Errorf("resolve config: %w", err)
 	}
+	if !cfg.Valkey.Enabled {
+		return nil
+	}
 
 	registry, err := do.Invoke[*telemetry.Registry](c)
 	if err != nil {
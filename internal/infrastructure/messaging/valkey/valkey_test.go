@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/do/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	valkeygo "github.com/valkey-io/valkey-go"
 
 	"github.com/zercle/zercle-go-template/internal/config"
 	"github.com/zercle/zercle-go-template/internal/infrastructure/messaging/valkey"
@@ -19,6 +21,17 @@ func TestConfig_ValkeyAddr(t *testing.T) {
 	require.Equal(t, "127.0.0.1:6379", cfg.ValkeyAddr())
 }
 
+func TestRegister_DisabledProvidesNoClient(t *testing.T) {
+	t.Parallel()
+
+	injector := do.New()
+	do.ProvideValue(injector, &config.Config{Valkey: config.ValkeyConfig{Enabled: false}})
+
+	require.NoError(t, valkey.Register(context.Background(), injector))
+	_, err := do.Invoke[valkeygo.Client](injector)
+	require.ErrorIs(t, err, do.ErrServiceNotFound)
+}
+
 func TestNewClient_Unreachable(t *testing.T) {
 	t.Parallel()
 
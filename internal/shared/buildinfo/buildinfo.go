@@ -0,0 +1,72 @@
+// Package buildinfo reports which build of the service is running. The
+// variables are set at link time:
+//
+//	go build -ldflags "-X github.com/zercle/zercle-go-template/internal/shared/buildinfo.Version=v1.2.3 \
+//	  -X github.com/zercle/zercle-go-template/internal/shared/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/zercle/zercle-go-template/internal/shared/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Unknown is reported for metadata that was neither linked in nor recorded
+// by the Go toolchain.
+const Unknown = "unknown"
+
+// Link-time build metadata. Empty values fall back to the module and VCS
+// data embedded by the Go toolchain, then to Unknown.
+var (
+	Version   = ""
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info is the build metadata served by /version and logged at startup.
+type Info struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build metadata for the application called name.
+func Get(name string) Info {
+	return resolve(name, Version, Commit, BuildTime, debug.ReadBuildInfo)
+}
+
+func resolve(name, version, commit, buildTime string, read func() (*debug.BuildInfo, bool)) Info {
+	if bi, ok := read(); ok {
+		if version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if commit == "" {
+					commit = s.Value
+				}
+			case "vcs.time":
+				if buildTime == "" {
+					buildTime = s.Value
+				}
+			}
+		}
+	}
+	return Info{
+		Name:      orUnknown(name),
+		Version:   orUnknown(version),
+		Commit:    orUnknown(commit),
+		BuildTime: orUnknown(buildTime),
+		GoVersion: runtime.Version(),
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return Unknown
+	}
+	return s
+}
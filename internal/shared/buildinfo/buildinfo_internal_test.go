@@ -0,0 +1,68 @@
+//go:build unit
+
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve_LinkedValuesWin(t *testing.T) {
+	t.Parallel()
+
+	read := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main:     debug.Module{Version: "v0.0.1"},
+			Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "fromvcs"}},
+		}, true
+	}
+
+	got := resolve("svc", "v1.2.3", "abc123", "2026-01-02T03:04:05Z", read)
+	assert.Equal(t, Info{
+		Name:      "svc",
+		Version:   "v1.2.3",
+		Commit:    "abc123",
+		BuildTime: "2026-01-02T03:04:05Z",
+		GoVersion: runtime.Version(),
+	}, got)
+}
+
+func TestResolve_FallsBackToToolchainMetadata(t *testing.T) {
+	t.Parallel()
+
+	read := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Version: "v0.4.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "deadbeef"},
+				{Key: "vcs.time", Value: "2026-05-06T07:08:09Z"},
+			},
+		}, true
+	}
+
+	got := resolve("svc", "", "", "", read)
+	assert.Equal(t, "v0.4.0", got.Version)
+	assert.Equal(t, "deadbeef", got.Commit)
+	assert.Equal(t, "2026-05-06T07:08:09Z", got.BuildTime)
+}
+
+func TestResolve_MissingMetadataIsUnknown(t *testing.T) {
+	t.Parallel()
+
+	devel := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}, true
+	}
+	none := func() (*debug.BuildInfo, bool) { return nil, false }
+
+	for _, read := range []func() (*debug.BuildInfo, bool){devel, none} {
+		got := resolve("", "", "", "", read)
+		assert.Equal(t, Unknown, got.Name)
+		assert.Equal(t, Unknown, got.Version)
+		assert.Equal(t, Unknown, got.Commit)
+		assert.Equal(t, Unknown, got.BuildTime)
+		assert.NotEmpty(t, got.GoVersion)
+	}
+}
@@ -20,6 +20,9 @@ type AppError struct {
 	HTTPStatus int
 	// GRPCCode is the gRPC status code that should be returned.
 	GRPCCode codes.Code
+	// Details maps fields to per-field messages, such as validation
+	// failures. It is included in HTTP bodies when non-empty.
+	Details map[string]string
 	// Cause is the underlying error, if any, preserved for observability.
 	Cause error
 }
@@ -47,7 +50,9 @@ var (
 	ErrUnauthorized     = &AppError{Code: "UNAUTHORIZED", Message: "unauthorized", HTTPStatus: http.StatusUnauthorized, GRPCCode: codes.Unauthenticated}
 	ErrForbidden        = &AppError{Code: "FORBIDDEN", Message: "forbidden", HTTPStatus: http.StatusForbidden, GRPCCode: codes.PermissionDenied}
 	ErrConflict         = &AppError{Code: "CONFLICT", Message: "conflict", HTTPStatus: http.StatusConflict, GRPCCode: codes.AlreadyExists}
+	ErrNotAcceptable    = &AppError{Code: "NOT_ACCEPTABLE", Message: "requested representation is not available", HTTPStatus: http.StatusNotAcceptable, GRPCCode: codes.InvalidArgument}
 	ErrCanceled         = &AppError{Code: "CANCELED", Message: "request canceled", HTTPStatus: 499, GRPCCode: codes.Canceled}
 	ErrDeadlineExceeded = &AppError{Code: "DEADLINE_EXCEEDED", Message: "deadline exceeded", HTTPStatus: http.StatusGatewayTimeout, GRPCCode: codes.DeadlineExceeded}
+	ErrUnavailable      = &AppError{Code: "UNAVAILABLE", Message: "service unavailable", HTTPStatus: http.StatusServiceUnavailable, GRPCCode: codes.Unavailable}
 	ErrInternal         = &AppError{Code: "INTERNAL", Message: "internal error", HTTPStatus: http.StatusInternalServerError, GRPCCode: codes.Internal}
 )
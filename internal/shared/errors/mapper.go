@@ -9,23 +9,42 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ErrorBody is the JSON body HTTPError builds. The HTTP servers add the
+// request's "request_id" when they serialize it.
+type ErrorBody map[string]any
+
 // HTTPError maps any error to an HTTP status code and a JSON-shaped response
 // body. A nil error maps to 200 with a success body.
-func HTTPError(err error) (int, map[string]any) {
+func HTTPError(err error) (int, ErrorBody) {
 	if err == nil {
-		return http.StatusOK, map[string]any{"status": "ok"}
+		return http.StatusOK, ErrorBody{"status": "ok"}
 	}
 
 	app := resolveAppError(err)
 
-	body := map[string]any{
+	body := ErrorBody{
 		"error":   app.Code,
 		"message": app.Message,
 	}
+	if len(app.Details) > 0 {
+		body["details"] = app.Details
+	}
 
 	return app.HTTPStatus, body
 }
 
+// InvalidInput classifies a request-binding failure. It returns err unchanged
+// when err already carries an *AppError, such as the strict JSON decoder's
+// unknown-field error naming the field, and ErrInvalidInput otherwise, so a
+// malformed request always maps to 400 rather than 500.
+func InvalidInput(err error) error {
+	var app *AppError
+	if errors.As(err, &app) {
+		return err
+	}
+	return ErrInvalidInput
+}
+
 // GRPCErr maps any error to a gRPC status error. A nil error maps to nil.
 func GRPCErr(err error) error {
 	if err == nil {
@@ -53,6 +53,24 @@ func TestHTTPError_AppError(t *testing.T) {
 	}
 }
 
+func TestHTTPError_Details(t *testing.T) {
+	app := &sharederrors.AppError{
+		Code:       "INVALID_INPUT",
+		Message:    "name is a required field",
+		HTTPStatus: http.StatusBadRequest,
+		Details:    map[string]string{"name": "name is a required field"},
+	}
+	_, body := sharederrors.HTTPError(fmt.Errorf("bind: %w", app))
+	if got, ok := body["details"].(map[string]string); !ok || got["name"] != "name is a required field" {
+		t.Fatalf("expected details, got %v", body["details"])
+	}
+
+	_, body = sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+	if _, ok := body["details"]; ok {
+		t.Fatal("empty details must be omitted")
+	}
+}
+
 func TestHTTPError_RegisteredSentinel(t *testing.T) {
 	status, body := sharederrors.HTTPError(errDomainSentinel)
 	if status != http.StatusNotFound {
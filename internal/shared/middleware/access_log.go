@@ -2,17 +2,40 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/rs/zerolog"
+
+	"github.com/zercle/zercle-go-template/pkg/logsample"
 )
 
+// AccessLogOption configures AccessLog.
+type AccessLogOption func(*accessLogOptions)
+
+type accessLogOptions struct {
+	sampler *logsample.Sampler
+}
+
+// WithSampler samples access log lines per method and route pattern. Lines
+// for 5xx responses are always written.
+func WithSampler(s *logsample.Sampler) AccessLogOption {
+	return func(o *accessLogOptions) { o.sampler = s }
+}
+
 // AccessLog returns echo middleware that emits one structured log line per
-// HTTP request with method, path, status, latency, and request id.
-func AccessLog(logger *zerolog.Logger) echo.MiddlewareFunc {
+// HTTP request with method, path, status, latency, and request id. Requests
+// abandoned by a disconnected client are logged at debug level: they are
+// expected and not actionable.
+func AccessLog(logger *zerolog.Logger, opts ...AccessLogOption) echo.MiddlewareFunc {
+	var o accessLogOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			start := time.Now()
@@ -21,7 +44,15 @@ func AccessLog(logger *zerolog.Logger) echo.MiddlewareFunc {
 
 			status := responseStatus(c, err)
 
-			logger.Info().
+			level := zerolog.InfoLevel
+			if clientGone(c, err) {
+				level = zerolog.DebugLevel
+			}
+			if status < http.StatusInternalServerError && !o.sampler.Allow(sampleKey(c), level) {
+				return err
+			}
+
+			logger.WithLevel(level).
 				Str("request_id", RequestIDFromContext(c)).
 				Str("method", c.Request().Method).
 				Str("path", c.Request().URL.Path).
@@ -34,16 +65,58 @@ func AccessLog(logger *zerolog.Logger) echo.MiddlewareFunc {
 	}
 }
 
+// unmatchedRoute is the sample key path of requests that matched no route.
+const unmatchedRoute = "<unmatched>"
+
+// sampleKey returns the access log sampling key: the method and the route
+// pattern. Both come from fixed sets, since the sampler keeps a counter per
+// key: methods other than the standard ones become OTHER, and requests that
+// matched no route share one key.
+func sampleKey(c *echo.Context) string {
+	method := c.Request().Method
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace:
+	default:
+		method = "OTHER"
+	}
+	route := c.Path()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	return method + " " + route
+}
+
+// StatusClientClosedRequest is the non-standard status (nginx's 499)
+// reported for requests whose client disconnected before the response.
+const StatusClientClosedRequest = 499
+
+// clientGone reports whether the request was abandoned by its client: the
+// request context was canceled, as net/http does when the connection drops,
+// rather than timing out.
+func clientGone(c *echo.Context, err error) bool {
+	if !errors.Is(c.Request().Context().Err(), context.Canceled) {
+		return false
+	}
+	return err == nil || errors.Is(err, context.Canceled)
+}
+
 // responseStatus returns the HTTP status for the current echo context. It
-// prefers an explicit echo.HTTPError from the handler chain and falls back to
+// prefers a status carried by the error, such as an echo.HTTPError or
+// echo's route-not-found error, and falls back to
 // the response status already recorded on the echo Response. A plain
 // (non-HTTPError) error from a handler indicates echo's central error handler
-// will turn it into a 500, which is what we report.
+// will turn it into a 500, which is what we report — unless the error is the
+// cancellation of a disconnected client, reported as 499 so it is not counted
+// as a server failure.
 func responseStatus(c *echo.Context, err error) int {
 	if err != nil {
-		var httpErr *echo.HTTPError
-		if errors.As(err, &httpErr) && httpErr.Code != 0 {
-			return httpErr.Code
+		var coder echo.HTTPStatusCoder
+		if errors.As(err, &coder) && coder.StatusCode() != 0 {
+			return coder.StatusCode()
+		}
+		if clientGone(c, err) {
+			return StatusClientClosedRequest
 		}
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,90 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func newAllowedHostsEcho(cfg middleware.AllowedHostsConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.AllowedHosts(cfg))
+	e.GET("/ping", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/healthz", func(c *echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	return e
+}
+
+func serveHost(e *echo.Echo, path, host string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Host = host
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAllowedHosts(t *testing.T) {
+	t.Parallel()
+
+	e := newAllowedHostsEcho(middleware.AllowedHostsConfig{
+		Hosts: []string{"api.example.com", "*.tenant.example.com", "[::1]"},
+	})
+
+	tests := []struct {
+		host string
+		want int
+	}{
+		{"api.example.com", http.StatusNoContent},
+		{"API.Example.com:8443", http.StatusNoContent},
+		{"api.example.com.", http.StatusNoContent},
+		{"acme.tenant.example.com", http.StatusNoContent},
+		{"a.b.tenant.example.com", http.StatusNoContent},
+		{"[::1]:8080", http.StatusNoContent},
+		{"tenant.example.com", http.StatusBadRequest},
+		{"evil.com", http.StatusBadRequest},
+		{"api.example.com.evil.com", http.StatusBadRequest},
+		{"eviltenant.example.com", http.StatusBadRequest},
+		{"", http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			t.Parallel()
+			rec := serveHost(e, "/ping", tc.host)
+			require.Equal(t, tc.want, rec.Code)
+			if tc.want == http.StatusBadRequest {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Equal(t, "HOST_NOT_ALLOWED", body["error"])
+			}
+		})
+	}
+}
+
+func TestAllowedHosts_EmptyAllowsAll(t *testing.T) {
+	t.Parallel()
+
+	e := newAllowedHostsEcho(middleware.AllowedHostsConfig{})
+	require.Equal(t, http.StatusNoContent, serveHost(e, "/ping", "anything.example").Code)
+}
+
+func TestAllowedHosts_Skip(t *testing.T) {
+	t.Parallel()
+
+	e := newAllowedHostsEcho(middleware.AllowedHostsConfig{
+		Hosts: []string{"api.example.com"},
+		Skip:  func(c *echo.Context) bool { return c.Request().URL.Path == "/healthz" },
+	})
+	require.Equal(t, http.StatusOK, serveHost(e, "/healthz", "10.0.0.7:8080").Code)
+	require.Equal(t, http.StatusBadRequest, serveHost(e, "/ping", "10.0.0.7:8080").Code)
+}
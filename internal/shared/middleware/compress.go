@@ -0,0 +1,266 @@
+// Echo response compression middleware.
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v5"
+)
+
+// CompressConfig configures Compress.
+type CompressConfig struct {
+	// MinSize is the body size in bytes below which responses are sent
+	// uncompressed.
+	MinSize int
+	// Level is the gzip compression level; 0 selects gzip.DefaultCompression.
+	// Brotli always uses brotli.DefaultCompression.
+	Level int
+	// Skip, when set, leaves matching requests untouched.
+	Skip func(c *echo.Context) bool
+}
+
+// incompressibleTypes are media types that are already compressed or are
+// streamed, so compressing them would only add latency.
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/gzip",
+	"application/zip",
+	"application/zstd",
+	"application/x-brotli",
+	"application/octet-stream",
+	"text/event-stream",
+}
+
+// Compress returns echo middleware that compresses response bodies for
+// clients that accept it, with brotli when the client prefers br (or names
+// both at the same quality) and gzip otherwise. The body is buffered up to
+// MinSize bytes before deciding, so small bodies, bodies with an
+// incompressible Content-Type, bodies that already carry a
+// Content-Encoding, and responses flushed before MinSize is reached
+// (streams) are passed through unchanged. Vary: Accept-Encoding is set on
+// every response the middleware handles.
+func Compress(cfg CompressConfig) echo.MiddlewareFunc {
+	level := cfg.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	pools := map[string]*sync.Pool{
+		encodingGzip: {New: func() any {
+			w, err := gzip.NewWriterLevel(io.Discard, level)
+			if err != nil {
+				w, _ = gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+			}
+			return w
+		}},
+		encodingBrotli: {New: func() any {
+			return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression)
+		}},
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if cfg.Skip != nil && cfg.Skip(c) {
+				return next(c)
+			}
+
+			orig := c.Response()
+			orig.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+			encoding := negotiateEncoding(c.Request().Header.Get(echo.HeaderAcceptEncoding))
+			if encoding == "" {
+				return next(c)
+			}
+
+			cw := &compressWriter{ResponseWriter: orig, encoding: encoding, pool: pools[encoding], minSize: cfg.MinSize}
+			c.SetResponse(cw)
+			defer func() {
+				cw.finish()
+				c.SetResponse(orig)
+			}()
+			return next(c)
+		}
+	}
+}
+
+// Content codings Compress produces.
+const (
+	encodingGzip   = "gzip"
+	encodingBrotli = "br"
+)
+
+// negotiateEncoding picks the content coding for an Accept-Encoding header:
+// "br", "gzip", or "" when the client accepts neither with a non-zero
+// quality. A coding not named takes the quality of "*". Brotli wins when its
+// quality is higher than gzip's, or equal and br is named explicitly.
+func negotiateEncoding(header string) string {
+	brQ, gzipQ, starQ := -1.0, -1.0, -1.0
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "br":
+			brQ = q
+		case "gzip", "x-gzip":
+			gzipQ = q
+		case "*":
+			starQ = q
+		}
+	}
+	namedBr := brQ >= 0
+	if brQ < 0 {
+		brQ = starQ
+	}
+	if gzipQ < 0 {
+		gzipQ = starQ
+	}
+	switch {
+	case brQ > 0 && (brQ > gzipQ || brQ == gzipQ && namedBr):
+		return encodingBrotli
+	case gzipQ > 0:
+		return encodingGzip
+	}
+	return ""
+}
+
+// compressible reports whether a response with header h may be compressed.
+func compressible(h http.Header) bool {
+	if h.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(h.Get(echo.HeaderContentType))
+	if err != nil {
+		return true
+	}
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// encoder is the part of *gzip.Writer and *brotli.Writer compressWriter
+// uses.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressWriter buffers the start of the body until it can decide whether
+// to compress, then writes either the negotiated encoding or identity to the
+// wrapped writer.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	pool     *sync.Pool
+	minSize  int
+
+	buf     []byte
+	status  int
+	decided bool
+	enc     encoder
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends what is buffered. A response flushed before reaching MinSize
+// is treated as a stream and is not compressed.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide commits the headers and the buffered body, compressing when allowed
+// and the response is compressible.
+func (w *compressWriter) decide(allow bool) error {
+	w.decided = true
+
+	h := w.Header()
+	if h.Get(echo.HeaderContentType) == "" && len(w.buf) > 0 {
+		h.Set(echo.HeaderContentType, http.DetectContentType(w.buf))
+	}
+	if allow && compressible(h) {
+		h.Del(echo.HeaderContentLength)
+		h.Set(echo.HeaderContentEncoding, w.encoding)
+		enc, _ := w.pool.Get().(encoder)
+		enc.Reset(w.ResponseWriter)
+		w.enc = enc
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.enc != nil {
+		_, err := w.enc.Write(buf)
+		return err //nolint:wrapcheck // surfaced to the handler as a write error.
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err //nolint:wrapcheck // surfaced to the handler as a write error.
+}
+
+// finish flushes a body that never reached MinSize and closes the
+// compressed stream.
+func (w *compressWriter) finish() {
+	if !w.decided && (w.status != 0 || len(w.buf) > 0) {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+		w.pool.Put(w.enc)
+		w.enc = nil
+	}
+}
@@ -0,0 +1,174 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func newCompressEcho(cfg middleware.CompressConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.Compress(cfg))
+	e.GET("/big", func(c *echo.Context) error {
+		return c.String(http.StatusOK, strings.Repeat("a", 4096))
+	})
+	e.GET("/small", func(c *echo.Context) error {
+		return c.String(http.StatusOK, "tiny")
+	})
+	e.GET("/png", func(c *echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", make([]byte, 4096))
+	})
+	e.GET("/stream", func(c *echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+		c.Response().WriteHeader(http.StatusOK)
+		for range 3 {
+			_, _ = io.WriteString(c.Response(), "data: "+strings.Repeat("x", 600)+"\n\n")
+			_ = http.NewResponseController(c.Response()).Flush()
+		}
+		return nil
+	})
+	e.GET("/flushed", func(c *echo.Context) error {
+		c.Response().WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(c.Response(), "chunk")
+		_ = http.NewResponseController(c.Response()).Flush()
+		_, _ = io.WriteString(c.Response(), strings.Repeat("b", 4096))
+		return nil
+	})
+	return e
+}
+
+func doCompressRequest(e *echo.Echo, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompress_OverThreshold(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	rec := doCompressRequest(e, "/big", "gzip")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Less(t, rec.Body.Len(), 4096)
+
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 4096), string(body))
+}
+
+func TestCompress_Brotli(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	rec := doCompressRequest(e, "/big", "gzip, deflate, br")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "br", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Less(t, rec.Body.Len(), 4096)
+
+	body, err := io.ReadAll(brotli.NewReader(rec.Body))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 4096), string(body))
+}
+
+func TestCompress_NegotiatesByQuality(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"br", "br"},
+		{"br;q=1.0, gzip;q=0.8", "br"},
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip", "gzip"},
+		{"gzip, br;q=0", "gzip"},
+		{"br, gzip;q=0", "br"},
+		{"*", "gzip"},
+		{"*, br;q=0.1", "gzip"},
+		{"gzip;q=0.5, *", "br"},
+	}
+	for _, tc := range tests {
+		rec := doCompressRequest(e, "/big", tc.accept)
+
+		assert.Equal(t, tc.want, rec.Header().Get(echo.HeaderContentEncoding), "Accept-Encoding %q", tc.accept)
+	}
+}
+
+func TestCompress_UnderThreshold(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	rec := doCompressRequest(e, "/small", "gzip")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+	assert.Equal(t, "tiny", rec.Body.String())
+}
+
+func TestCompress_NotAccepted(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	for _, accept := range []string{"", "identity", "gzip;q=0", "deflate", "*;q=0", "br;q=0, gzip;q=0"} {
+		rec := doCompressRequest(e, "/big", accept)
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding), "Accept-Encoding %q", accept)
+		assert.Equal(t, 4096, rec.Body.Len(), "Accept-Encoding %q", accept)
+	}
+
+	rec := doCompressRequest(e, "/big", "*")
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+}
+
+func TestCompress_SkipsIncompressibleTypes(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	rec := doCompressRequest(e, "/png", "gzip")
+
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, 4096, rec.Body.Len())
+}
+
+func TestCompress_SkipsStreams(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{MinSize: 1024})
+
+	for _, path := range []string{"/stream", "/flushed"} {
+		rec := doCompressRequest(e, path, "gzip")
+
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding), path)
+		assert.True(t, rec.Flushed, path)
+	}
+}
+
+func TestCompress_Skip(t *testing.T) {
+	e := newCompressEcho(middleware.CompressConfig{
+		MinSize: 1024,
+		Skip:    func(c *echo.Context) bool { return c.Request().URL.Path == "/big" },
+	})
+
+	rec := doCompressRequest(e, "/big", "gzip")
+
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Empty(t, rec.Header().Get(echo.HeaderVary))
+}
@@ -0,0 +1,36 @@
+// Echo middleware that attaches a request-scoped logger to the context.
+package middleware
+
+import (
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextLogger returns echo middleware that stores a child of logger, enriched
+// with the request id, method, route pattern and (when a span is active) the
+// trace id, in the request context. Handlers and the code they call log
+// through telemetry.LoggerFromContext instead of re-threading those fields.
+// It must run after RequestID and OTel.
+func ContextLogger(logger *zerolog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			req := c.Request()
+			ctx := req.Context()
+
+			lctx := logger.With().
+				Str("request_id", RequestIDFromContext(c)).
+				Str("method", req.Method)
+			if route := c.Path(); route != "" {
+				lctx = lctx.Str("route", route)
+			}
+			if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+				lctx = lctx.Str("trace_id", sc.TraceID().String())
+			}
+			l := lctx.Logger()
+
+			c.SetRequest(req.WithContext(l.WithContext(ctx)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+)
+
+func TestContextLogger_EnrichesFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.Use(middleware.ContextLogger(&logger))
+	e.GET("/items/:id", func(c *echo.Context) error {
+		telemetry.LoggerFromContext(c.Request().Context()).Info().Msg("handled")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "handled", entry["message"])
+	assert.Equal(t, "req-123", entry["request_id"])
+	assert.Equal(t, http.MethodGet, entry["method"])
+	assert.Equal(t, "/items/:id", entry["route"])
+}
@@ -2,6 +2,10 @@
 package middleware
 
 import (
+	"net/url"
+	"slices"
+	"strings"
+
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
 
@@ -25,7 +29,10 @@ var defaultCORSExposeHeaders = []string{"Content-Length"}
 const defaultCORSMaxAge = 86400
 
 // CORS returns echo's built-in CORS middleware configured from cfg.HTTP.CORS*.
-// When no origins are configured it defaults to allowing all origins. A nil
+// When no origins are configured it defaults to allowing all origins. Besides
+// exact origins and "*", an origin may be a subdomain pattern such as
+// "https://*.example.com", which allows any subdomain of example.com over
+// that scheme and port but not example.com itself. A nil
 // cfg yields the package CORS defaults (allow all origins, standard
 // methods/headers, Content-Length exposed, 24h preflight cache).
 func CORS(cfg *config.Config) echo.MiddlewareFunc {
@@ -56,6 +63,47 @@ func CORS(cfg *config.Config) echo.MiddlewareFunc {
 	if len(corsCfg.AllowHeaders) == 0 {
 		corsCfg.AllowHeaders = defaultCORSHeaders
 	}
+	if !slices.Contains(corsCfg.AllowOrigins, "*") && slices.ContainsFunc(corsCfg.AllowOrigins, isSubdomainPattern) {
+		corsCfg.UnsafeAllowOriginFunc = allowOrigins(corsCfg.AllowOrigins)
+	}
 
 	return middleware.CORSWithConfig(corsCfg)
 }
+
+// isSubdomainPattern reports whether origin has the form scheme://*.host.
+func isSubdomainPattern(origin string) bool {
+	_, rest, ok := strings.Cut(origin, "://")
+	return ok && strings.HasPrefix(rest, "*.")
+}
+
+// allowOrigins matches a request origin against exact origins, compared
+// case-insensitively as echo does, and subdomain patterns. A pattern matches
+// only when the scheme and port are equal and the origin's host ends in
+// "." + the pattern's domain, so look-alikes such as https://evilexample.com
+// or https://example.com.evil.com are rejected.
+func allowOrigins(origins []string) func(*echo.Context, string) (string, bool, error) {
+	return func(_ *echo.Context, origin string) (string, bool, error) {
+		for _, allowed := range origins {
+			if strings.EqualFold(allowed, origin) {
+				return origin, true, nil
+			}
+			if isSubdomainPattern(allowed) && matchesSubdomain(allowed, origin) {
+				return origin, true, nil
+			}
+		}
+		return "", false, nil
+	}
+}
+
+func matchesSubdomain(pattern, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	scheme, rest, _ := strings.Cut(pattern, "://")
+	domain, port, _ := strings.Cut(strings.TrimPrefix(rest, "*"), ":")
+	host := strings.ToLower(u.Hostname())
+	sub, ok := strings.CutSuffix(host, strings.ToLower(domain))
+	return ok && sub != "" && !strings.HasSuffix(sub, ".") &&
+		strings.EqualFold(u.Scheme, scheme) && u.Port() == port
+}
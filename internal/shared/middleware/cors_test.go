@@ -79,3 +79,71 @@ func TestCORS_ConfigAppliesExposeAndMaxAge(t *testing.T) {
 		strings.Contains(exposed, "Content-Length"),
 		"expected Access-Control-Expose-Headers to contain Content-Length, got %q", exposed)
 }
+
+func TestCORS_SubdomainPattern(t *testing.T) {
+	cfg := &config.Config{
+		HTTP: config.HTTPConfig{
+			CORSAllowOrigins: []string{"https://*.example.com", "http://localhost:3000"},
+		},
+	}
+
+	e := echo.New()
+	e.Use(middleware.CORS(cfg))
+	e.GET("/ok", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://app.example.com", true},
+		{"https://a.b.example.com", true},
+		{"HTTPS://App.Example.com", true},
+		{"http://localhost:3000", true},
+		{"https://example.com", false},
+		{"http://app.example.com", false},
+		{"https://app.example.com:8443", false},
+		{"https://evilexample.com", false},
+		{"https://app.example.com.evil.com", false},
+		{"https://evil.com", false},
+		{"https://evil.com/.example.com", false},
+		{"https://user@app.example.com", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+			req.Header.Set("Origin", tc.origin)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			got := rec.Header().Get("Access-Control-Allow-Origin")
+			if tc.allowed {
+				require.Equal(t, tc.origin, got)
+			} else {
+				require.Empty(t, got)
+			}
+		})
+	}
+}
+
+func TestCORS_StarIgnoresPatterns(t *testing.T) {
+	cfg := &config.Config{
+		HTTP: config.HTTPConfig{
+			CORSAllowOrigins: []string{"https://*.example.com", "*"},
+		},
+	}
+
+	e := echo.New()
+	e.Use(middleware.CORS(cfg))
+	e.GET("/ok", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,50 @@
+// Echo middleware for connection draining during shutdown.
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/labstack/echo/v5"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+)
+
+// Drainer is the shutdown flag shared between the Drain middleware and the
+// server shutdown path. The zero value is ready to use and not draining.
+type Drainer struct {
+	draining atomic.Bool
+}
+
+// NewDrainer returns a Drainer that is not draining.
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+// Start flips the drainer into draining mode. It is idempotent.
+func (d *Drainer) Start() {
+	d.draining.Store(true)
+}
+
+// Draining reports whether Start has been called.
+func (d *Drainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// Drain returns echo middleware that, once d is draining, rejects new
+// requests with the shared 503 UNAVAILABLE body and a Connection: close
+// header so keep-alive clients stop reusing the connection and reconnect to
+// another instance.
+//
+// nolint:wrapcheck // echo middleware returns the JSON write error directly.
+func Drain(d *Drainer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if d.Draining() {
+				c.Response().Header().Set(echo.HeaderConnection, "close")
+				status, body := sharederrors.HTTPError(sharederrors.ErrUnavailable)
+				return c.JSON(status, body)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func TestDrain(t *testing.T) {
+	drainer := middleware.NewDrainer()
+	e := echo.New()
+	e.Use(middleware.Drain(drainer))
+	e.GET("/ping", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Empty(t, rec.Header().Get("Connection"))
+
+	drainer.Start()
+	require.True(t, drainer.Draining())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "close", rec.Header().Get("Connection"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "UNAVAILABLE", body["error"])
+}
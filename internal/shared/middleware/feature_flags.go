@@ -0,0 +1,57 @@
+// Echo middleware for per-request feature flag evaluation.
+package middleware
+
+import (
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+// FlagSubjectFunc returns the stable identity percentage rollouts are hashed
+// on for a request. Returning "" opts the request out of partial rollouts.
+type FlagSubjectFunc func(c *echo.Context) string
+
+// ClientIPSubject is a FlagSubjectFunc keyed on the client IP from
+// c.RealIP. The server resolves that from the connection, and from
+// X-Forwarded-For only when the peer is a configured trusted proxy
+// (HTTP_TRUSTED_PROXIES), so a client cannot pick its own rollout bucket.
+// It suits anonymous traffic; features with authenticated users should key
+// on the user id instead so a rollout follows the user across devices.
+func ClientIPSubject(c *echo.Context) string {
+	return c.RealIP()
+}
+
+// FeatureFlagsConfig configures FeatureFlags.
+type FeatureFlagsConfig struct {
+	Provider featureflag.Provider
+	Subject  FlagSubjectFunc
+	Logger   *zerolog.Logger
+	// Skip, when set, leaves matching requests without a flag set, e.g.
+	// health probes that must not wait on the flag source.
+	Skip func(c *echo.Context) bool
+}
+
+// FeatureFlags returns echo middleware that evaluates every flag from
+// cfg.Provider for the request's subject and stores the result in the
+// request context, where featureflag.IsEnabled reads it. If the provider
+// fails, the request continues with every flag off, as do skipped requests.
+func FeatureFlags(cfg FeatureFlagsConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if cfg.Skip != nil && cfg.Skip(c) {
+				return next(c)
+			}
+			req := c.Request()
+			flags, err := cfg.Provider.Flags(req.Context())
+			if err != nil {
+				cfg.Logger.Warn().Err(err).Str("request_id", RequestIDFromContext(c)).Msg("feature flags unavailable")
+			}
+
+			set := featureflag.Evaluate(flags, cfg.Subject(c))
+			c.SetRequest(req.WithContext(featureflag.NewContext(req.Context(), set)))
+
+			return next(c)
+		}
+	}
+}
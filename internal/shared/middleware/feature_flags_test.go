@@ -0,0 +1,105 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+type failingFlagProvider struct{}
+
+func (failingFlagProvider) Flags(context.Context) (map[string]featureflag.Flag, error) {
+	return nil, errors.New("unavailable")
+}
+
+func newFeatureFlagEcho(t *testing.T, provider featureflag.Provider) *echo.Echo {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	e := echo.New()
+	e.Use(middleware.FeatureFlags(middleware.FeatureFlagsConfig{
+		Provider: provider,
+		Subject: func(c *echo.Context) string {
+			return c.Request().Header.Get("X-Subject")
+		},
+		Logger: &logger,
+		Skip: func(c *echo.Context) bool {
+			return c.Request().URL.Path == "/healthz"
+		},
+	}))
+	e.GET("/healthz", func(c *echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/flag/:key", func(c *echo.Context) error {
+		if featureflag.IsEnabled(c.Request().Context(), c.Param("key")) {
+			return c.String(http.StatusOK, "on")
+		}
+		return c.String(http.StatusOK, "off")
+	})
+	return e
+}
+
+func TestFeatureFlags_AttachesEvaluatedSet(t *testing.T) {
+	provider := featureflag.NewStatic(
+		featureflag.Flag{Key: "everyone", Enabled: true, RolloutPercent: 100},
+		featureflag.Flag{Key: "nobody", Enabled: false, RolloutPercent: 100},
+	)
+	e := newFeatureFlagEcho(t, provider)
+
+	for key, want := range map[string]string{"everyone": "on", "nobody": "off", "unknown": "off"} {
+		req := httptest.NewRequest(http.MethodGet, "/flag/"+key, nil)
+		req.Header.Set("X-Subject", "u1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, want, rec.Body.String(), key)
+	}
+}
+
+func TestFeatureFlags_ProviderErrorTurnsFlagsOff(t *testing.T) {
+	e := newFeatureFlagEcho(t, failingFlagProvider{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flag/anything", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "off", rec.Body.String())
+}
+
+// countingFlagProvider counts Flags calls.
+type countingFlagProvider struct {
+	calls atomic.Int32
+}
+
+func (p *countingFlagProvider) Flags(context.Context) (map[string]featureflag.Flag, error) {
+	p.calls.Add(1)
+	return nil, nil
+}
+
+func TestFeatureFlags_SkipsMatchingRequests(t *testing.T) {
+	provider := &countingFlagProvider{}
+	e := newFeatureFlagEcho(t, provider)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Zero(t, provider.calls.Load())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flag/any", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.EqualValues(t, 1, provider.calls.Load())
+}
@@ -0,0 +1,19 @@
+// Echo middleware that applies JSON request body limits.
+package middleware
+
+import (
+	"github.com/labstack/echo/v5"
+
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+)
+
+// JSONLimits makes limits the nesting and element-count bounds that c.Bind
+// and request.BindStrict enforce on JSON request bodies.
+func JSONLimits(limits request.Limits) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			request.SetLimits(c, limits)
+			return next(c)
+		}
+	}
+}
@@ -4,10 +4,14 @@ package middleware_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/rs/zerolog"
@@ -15,6 +19,7 @@ import (
 
 	"github.com/zercle/zercle-go-template/internal/config"
 	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/pkg/logsample"
 )
 
 func TestRecover_CatchesPanic(t *testing.T) {
@@ -72,6 +77,76 @@ func TestAccessLog_WritesLogLine(t *testing.T) {
 	require.Contains(t, buf.String(), "204")
 }
 
+func TestAccessLog_ClientDisconnectLoggedAtDebug(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	e := echo.New()
+	e.Use(middleware.AccessLog(&logger))
+	e.GET("/slow", func(c *echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.Request().Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Contains(t, buf.String(), `"level":"debug"`)
+	require.Contains(t, buf.String(), `"status":499`)
+	require.NotContains(t, buf.String(), `"status":500`)
+}
+
+func TestAccessLog_SamplesPerRouteButNotServerErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	sampler := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel: {First: 2},
+	})
+
+	e := echo.New()
+	e.Use(middleware.AccessLog(&logger, middleware.WithSampler(sampler)))
+	e.GET("/items/:id", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/fail", func(c *echo.Context) error {
+		return c.NoContent(http.StatusInternalServerError)
+	})
+
+	for i := range 5 {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/"+strconv.Itoa(i), nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	}
+
+	require.Equal(t, 2, strings.Count(buf.String(), `"status":204`))
+	require.Equal(t, 5, strings.Count(buf.String(), `"status":500`))
+}
+
+func TestAccessLog_SampleKeysAreBounded(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	sampler := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel: {First: 1},
+	})
+
+	e := echo.New()
+	e.Use(middleware.AccessLog(&logger, middleware.WithSampler(sampler)))
+	e.Any("/items", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Made-up methods share one key, as do paths that match no route.
+	for i := range 5 {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("M"+strconv.Itoa(i), "/items", nil))
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing/"+strconv.Itoa(i), nil))
+	}
+
+	require.Equal(t, 1, strings.Count(buf.String(), `"status":204`))
+	require.Equal(t, 1, strings.Count(buf.String(), `"status":404`))
+}
+
 func TestCORS_SetsHeaders(t *testing.T) {
 	cfg := &config.Config{
 		HTTP: config.HTTPConfig{
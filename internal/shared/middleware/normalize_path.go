@@ -0,0 +1,91 @@
+// Echo pre-routing middleware that canonicalizes request paths.
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+// NormalizePathConfig configures NormalizePath.
+type NormalizePathConfig struct {
+	// Redirect answers GET and HEAD requests for a non-canonical path with a
+	// 308 to the canonical one. Other methods are always rewritten in place
+	// so their bodies are not lost to a client that will not resend them.
+	// When false, every method is rewritten.
+	Redirect bool
+	// Skip, when set, leaves matching requests untouched, e.g. routes that
+	// are registered with a trailing slash.
+	Skip func(c *echo.Context) bool
+}
+
+// NormalizePath returns echo middleware that collapses repeated slashes and
+// drops a trailing slash from the request path, so /api/v1//items/ reaches
+// the /api/v1/items route. It must be installed with (*echo.Echo).Pre to run
+// before routing. Paths that are already canonical pass through unchanged,
+// and paths that are still unknown after normalizing get the usual 404.
+//
+// nolint:wrapcheck // echo middleware returns the redirect write error directly.
+func NormalizePath(cfg NormalizePathConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if cfg.Skip != nil && cfg.Skip(c) {
+				return next(c)
+			}
+			req := c.Request()
+			u := *req.URL
+			if u.RawPath != "" {
+				raw := canonicalPath(u.RawPath)
+				if raw == u.RawPath {
+					return next(c)
+				}
+				p, err := url.PathUnescape(raw)
+				if err != nil {
+					return next(c)
+				}
+				u.Path, u.RawPath = p, raw
+			} else {
+				p := canonicalPath(u.Path)
+				if p == u.Path {
+					return next(c)
+				}
+				u.Path = p
+			}
+
+			if cfg.Redirect && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+				// EscapedPath escapes backslashes, so the Location cannot
+				// be read as a protocol-relative URL such as /\evil.com.
+				location := u.EscapedPath()
+				if u.RawQuery != "" {
+					location += "?" + u.RawQuery
+				}
+				return c.Redirect(http.StatusPermanentRedirect, location)
+			}
+			req.URL.Path, req.URL.RawPath = u.Path, u.RawPath
+			return next(c)
+		}
+	}
+}
+
+// canonicalPath collapses runs of slashes in p into one and removes a
+// trailing slash, keeping the root path "/".
+func canonicalPath(p string) string {
+	if !strings.Contains(p, "//") && (len(p) <= 1 || !strings.HasSuffix(p, "/")) {
+		return p
+	}
+	var b strings.Builder
+	b.Grow(len(p))
+	for i := 0; i < len(p); i++ {
+		if p[i] == '/' && i > 0 && p[i-1] == '/' {
+			continue
+		}
+		b.WriteByte(p[i])
+	}
+	out := b.String()
+	if len(out) > 1 {
+		out = strings.TrimSuffix(out, "/")
+	}
+	return out
+}
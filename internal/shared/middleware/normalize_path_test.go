@@ -0,0 +1,105 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func newNormalizeEcho(redirect bool) *echo.Echo {
+	e := echo.New()
+	e.Pre(middleware.NormalizePath(middleware.NormalizePathConfig{
+		Redirect: redirect,
+		Skip: func(c *echo.Context) bool {
+			return strings.HasPrefix(c.Request().URL.Path, "/raw/")
+		},
+	}))
+	e.GET("/", func(c *echo.Context) error {
+		return c.String(http.StatusOK, "root")
+	})
+	e.GET("/api/v1/items", func(c *echo.Context) error {
+		return c.String(http.StatusOK, "list "+c.QueryParam("limit"))
+	})
+	e.POST("/api/v1/items", func(c *echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusCreated, string(body))
+	})
+	e.GET("/raw/", func(c *echo.Context) error {
+		return c.String(http.StatusOK, "raw")
+	})
+	return e
+}
+
+func TestNormalizePath_Rewrite(t *testing.T) {
+	e := newNormalizeEcho(false)
+
+	for _, path := range []string{"/api/v1/items", "/api/v1/items/", "//api/v1//items", "/api//v1///items//"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path+"?limit=5", nil))
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		require.Equal(t, "list 5", rec.Body.String(), path)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "root", rec.Body.String())
+}
+
+func TestNormalizePath_RedirectsGET(t *testing.T) {
+	e := newNormalizeEcho(true)
+
+	tests := []struct {
+		path     string
+		location string
+	}{
+		{"/api/v1/items/", "/api/v1/items"},
+		{"//api/v1//items?limit=5", "/api/v1/items?limit=5"},
+		{"//evil.com/", "/evil.com"},
+		{`/\evil.com/`, "/%5Cevil.com"},
+	}
+	for _, tc := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(method, tc.path, nil))
+			require.Equal(t, http.StatusPermanentRedirect, rec.Code, method+" "+tc.path)
+			require.Equal(t, tc.location, rec.Header().Get("Location"), method+" "+tc.path)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/items?limit=5", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNormalizePath_RewritesOtherMethods(t *testing.T) {
+	for _, redirect := range []bool{false, true} {
+		e := newNormalizeEcho(redirect)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "//api/v1/items/", strings.NewReader(`{"name":"a"}`)))
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.JSONEq(t, `{"name":"a"}`, rec.Body.String())
+	}
+}
+
+func TestNormalizePath_Skip(t *testing.T) {
+	e := newNormalizeEcho(true)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/raw/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "raw", rec.Body.String())
+}
@@ -2,8 +2,9 @@
 package middleware
 
 import (
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
+
+	"github.com/zercle/zercle-go-template/pkg/uuidgen"
 )
 
 // requestIDHeader is the header used to propagate or generate a request id.
@@ -46,7 +47,7 @@ func RequestID() echo.MiddlewareFunc {
 			req := c.Request()
 			id := req.Header.Get(requestIDHeader)
 			if !isValidRequestID(id) {
-				id = uuid.NewString()
+				id = uuidgen.NewString()
 			}
 
 			c.Set(string(requestIDKey), id)
@@ -0,0 +1,46 @@
+// Echo route middleware for UUID path parameters.
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+)
+
+// uuidParamKeyPrefix namespaces parsed UUID path parameters in the echo
+// context so they cannot collide with other context values.
+const uuidParamKeyPrefix = "uuid_param:"
+
+// UUIDParam returns route-level echo middleware that parses the named path
+// parameter as a UUID once, before the handler runs. Malformed values and the
+// nil UUID are rejected with invalid, written through sharederrors.HTTPError,
+// so a feature can pass its own sentinel (e.g. domain.ErrInvalidID) and get
+// the same body its service would produce. Valid values are stored in the
+// echo context for UUIDParamFromContext.
+//
+// nolint:wrapcheck // echo middleware returns the JSON write error directly.
+func UUIDParam(name string, invalid error) echo.MiddlewareFunc {
+	key := uuidParamKeyPrefix + name
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			id, err := uuid.Parse(c.Param(name))
+			if err != nil || id == uuid.Nil {
+				status, body := sharederrors.HTTPError(invalid)
+				return c.JSON(status, body)
+			}
+
+			c.Set(key, id)
+
+			return next(c)
+		}
+	}
+}
+
+// UUIDParamFromContext returns the UUID parsed by UUIDParam for the named path
+// parameter. The boolean is false when the route was not guarded by
+// UUIDParam(name).
+func UUIDParamFromContext(c *echo.Context, name string) (uuid.UUID, bool) {
+	id, ok := c.Get(uuidParamKeyPrefix + name).(uuid.UUID)
+	return id, ok
+}
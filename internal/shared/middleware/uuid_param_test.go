@@ -0,0 +1,103 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func newUUIDParamEcho(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	e := echo.New()
+	e.GET("/things/:id", func(c *echo.Context) error {
+		id, ok := middleware.UUIDParamFromContext(c, "id")
+		if !ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.String(http.StatusOK, id.String())
+	}, middleware.UUIDParam("id", sharederrors.ErrInvalidInput))
+	e.GET("/lookup/:id", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, middleware.UUIDParam("id", sharederrors.ErrNotFound))
+	e.GET("/unguarded/:id", func(c *echo.Context) error {
+		if _, ok := middleware.UUIDParamFromContext(c, "id"); ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	return e
+}
+
+func TestUUIDParam_StoresParsedValue(t *testing.T) {
+	e := newUUIDParamEcho(t)
+	id := uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/things/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, id.String(), rec.Body.String())
+}
+
+func TestUUIDParam_RejectsInvalidValues(t *testing.T) {
+	cases := []struct {
+		name string
+		id   string
+	}{
+		{name: "malformed", id: "abc"},
+		{name: "truncated", id: "12345678-1234-1234-1234"},
+		{name: "nil uuid", id: uuid.Nil.String()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newUUIDParamEcho(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/things/"+tc.id, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, "INVALID_INPUT", body["error"])
+		})
+	}
+}
+
+func TestUUIDParam_ReturnsGivenError(t *testing.T) {
+	e := newUUIDParamEcho(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/lookup/abc", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "NOT_FOUND", body["error"])
+}
+
+func TestUUIDParamFromContext_AbsentWithoutMiddleware(t *testing.T) {
+	e := newUUIDParamEcho(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/unguarded/"+uuid.NewString(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,19 @@
+// Echo middleware that labels responses with the running build.
+package middleware
+
+import "github.com/labstack/echo/v5"
+
+// versionHeader names the build that served a response.
+const versionHeader = "X-App-Version"
+
+// VersionHeader returns echo middleware that sets X-App-Version to version on
+// every response, so clients and load balancer logs can tell which build
+// answered during a rollout.
+func VersionHeader(version string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			c.Response().Header().Set(versionHeader, version)
+			return next(c)
+		}
+	}
+}
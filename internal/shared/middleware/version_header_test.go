@@ -0,0 +1,32 @@
+//go:build unit
+
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+)
+
+func TestVersionHeader(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.VersionHeader("v1.2.3"))
+	e.GET("/ok", func(c *echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fail", func(*echo.Context) error {
+		return errors.New("boom")
+	})
+
+	for _, path := range []string{"/ok", "/fail", "/missing"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, "v1.2.3", rec.Header().Get("X-App-Version"), path)
+	}
+}
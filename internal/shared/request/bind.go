@@ -0,0 +1,93 @@
+// Package request decodes HTTP request bodies for handlers.
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"google.golang.org/grpc/codes"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+)
+
+// unknownFieldPrefix is the prefix encoding/json uses for errors raised by
+// Decoder.DisallowUnknownFields.
+const unknownFieldPrefix = "json: unknown field "
+
+// BindStrict decodes the JSON request body into dst, rejecting fields dst
+// does not declare and any data after the JSON value. Unlike c.Bind it reads
+// only the body, never path or query parameters. An empty body leaves dst
+// untouched so validation reports the missing fields. The body must also fit
+// the request's Limits (see SetLimits).
+//
+// Use it for create and update endpoints; c.Bind remains available for
+// endpoints that must tolerate extra fields from older clients.
+func BindStrict(c *echo.Context, dst any) error {
+	req := c.Request()
+	if req.ContentLength == 0 {
+		return nil
+	}
+	return DecodeStrict(req.Body, dst, LimitsFrom(c))
+}
+
+// DecodeStrict decodes a single JSON value from r into dst after checking it
+// against limits. An unknown field yields a 400 INVALID_INPUT *AppError whose
+// message names the field; other malformed input yields a wrapped decoding
+// error.
+func DecodeStrict(r io.Reader, dst any, limits Limits) error {
+	return decode(r, dst, limits, true)
+}
+
+// Decode is the lenient counterpart of DecodeStrict: unknown fields and
+// trailing data are ignored, but limits still apply.
+func Decode(r io.Reader, dst any, limits Limits) error {
+	return decode(r, dst, limits, false)
+}
+
+func decode(r io.Reader, dst any, limits Limits, strict bool) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	if err := CheckLimits(data, limits); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix); ok {
+			return invalidInput("unknown field "+field, err)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return fmt.Errorf("decode json: %w", err)
+	}
+	if !strict {
+		return nil
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return invalidInput("unexpected data after JSON body", err)
+	}
+	return nil
+}
+
+// invalidInput builds a 400 INVALID_INPUT error with a specific message.
+func invalidInput(message string, cause error) *sharederrors.AppError {
+	return &sharederrors.AppError{
+		Code:       sharederrors.ErrInvalidInput.Code,
+		Message:    message,
+		HTTPStatus: http.StatusBadRequest,
+		GRPCCode:   codes.InvalidArgument,
+		Cause:      cause,
+	}
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+package request_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+)
+
+type createThing struct {
+	FullName string `json:"full_name"`
+	Age      int    `json:"age"`
+}
+
+func bindStrict(t *testing.T, body string) (createThing, error) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/things?full_name=query", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	var dst createThing
+	err := request.BindStrict(c, &dst)
+	return dst, err
+}
+
+func TestBindStrict_Valid(t *testing.T) {
+	t.Parallel()
+
+	got, err := bindStrict(t, `{"full_name":"Ada","age":36}`)
+
+	require.NoError(t, err)
+	require.Equal(t, createThing{FullName: "Ada", Age: 36}, got)
+}
+
+func TestBindStrict_EmptyBody(t *testing.T) {
+	t.Parallel()
+
+	got, err := bindStrict(t, "")
+
+	require.NoError(t, err)
+	require.Zero(t, got, "query parameters must not be bound")
+}
+
+func TestBindStrict_UnknownField(t *testing.T) {
+	t.Parallel()
+
+	_, err := bindStrict(t, `{"full_nmae":"Ada"}`)
+
+	var app *sharederrors.AppError
+	require.ErrorAs(t, err, &app)
+	status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "INVALID_INPUT", body["error"])
+	require.Equal(t, `unknown field "full_nmae"`, body["message"])
+}
+
+func TestBindStrict_TrailingData(t *testing.T) {
+	t.Parallel()
+
+	_, err := bindStrict(t, `{"full_name":"Ada"}{"age":1}`)
+
+	status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "unexpected data after JSON body", body["message"])
+}
+
+func TestBindStrict_Malformed(t *testing.T) {
+	t.Parallel()
+
+	_, err := bindStrict(t, `{"full_name":`)
+
+	require.Error(t, err)
+	var app *sharederrors.AppError
+	require.False(t, errors.As(err, &app), "malformed JSON keeps the generic invalid-input message")
+	status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "invalid input", body["message"])
+}
@@ -0,0 +1,111 @@
+// JSON request body shape limits.
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/labstack/echo/v5"
+)
+
+// limitsKey is the echo context key holding the request's Limits.
+const limitsKey = "request.limits"
+
+// Limits bounds the shape of a JSON request body. They are checked with a
+// cheap token scan before the body is decoded into a handler's type, so a
+// pathologically nested or wide document is rejected before reflection-heavy
+// decoding runs. Zero or negative fields fall back to DefaultLimits.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of arrays and objects.
+	MaxDepth int
+	// MaxElements is the maximum number of elements in any single array or
+	// members in any single object.
+	MaxElements int
+}
+
+// DefaultLimits are used when no limits are configured.
+var DefaultLimits = Limits{MaxDepth: 32, MaxElements: 10000}
+
+// withDefaults replaces unset fields with DefaultLimits.
+func (l Limits) withDefaults() Limits {
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultLimits.MaxDepth
+	}
+	if l.MaxElements <= 0 {
+		l.MaxElements = DefaultLimits.MaxElements
+	}
+	return l
+}
+
+// SetLimits stores the limits the bind helpers apply for this request.
+func SetLimits(c *echo.Context, l Limits) {
+	c.Set(limitsKey, l)
+}
+
+// LimitsFrom returns the limits stored by SetLimits, or DefaultLimits.
+func LimitsFrom(c *echo.Context) Limits {
+	if l, ok := c.Get(limitsKey).(Limits); ok {
+		return l.withDefaults()
+	}
+	return DefaultLimits
+}
+
+// frame tracks one open array or object during CheckLimits.
+type frame struct {
+	object   bool
+	elements int
+	keyNext  bool
+}
+
+// CheckLimits scans data and returns a 400 INVALID_INPUT *AppError when it
+// nests deeper than l.MaxDepth or any array or object holds more than
+// l.MaxElements entries. Malformed JSON is left for the decoder to report.
+func CheckLimits(data []byte, l Limits) error {
+	l = l.withDefaults()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	stack := make([]frame, 0, 8)
+
+	// addElement counts a value or key against the innermost container.
+	addElement := func(isKey bool) error {
+		if len(stack) == 0 {
+			return nil
+		}
+		top := &stack[len(stack)-1]
+		if top.object && !isKey {
+			top.keyNext = true
+			return nil
+		}
+		top.elements++
+		if top.object {
+			top.keyNext = false
+		}
+		if top.elements > l.MaxElements {
+			return invalidInput(fmt.Sprintf("JSON array or object exceeds %d elements", l.MaxElements), nil)
+		}
+		return nil
+	}
+
+	// The scan ends at EOF or at the first syntax error; malformed JSON is left
+	// for the decoder, which reports it with more context.
+	for tok, err := dec.Token(); err == nil; tok, err = dec.Token() {
+		isKey := len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].keyNext
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			if err := addElement(false); err != nil {
+				return err
+			}
+			if len(stack) == l.MaxDepth {
+				return invalidInput(fmt.Sprintf("JSON nesting exceeds %d levels", l.MaxDepth), nil)
+			}
+			stack = append(stack, frame{object: tok == json.Delim('{'), keyNext: true})
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		default:
+			if err := addElement(isKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build unit
+
+package request_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+)
+
+func nested(depth int) string {
+	return strings.Repeat("[", depth) + strings.Repeat("]", depth)
+}
+
+func array(n int) string {
+	return "[" + strings.TrimSuffix(strings.Repeat("1,", n), ",") + "]"
+}
+
+func object(n int) string {
+	var b strings.Builder
+	b.WriteString("{")
+	for i := range n {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(`"k` + strings.Repeat("x", i) + `":{}`)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func TestCheckLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := request.Limits{MaxDepth: 4, MaxElements: 3}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantMsg string
+	}{
+		{"scalar", `"text"`, ""},
+		{"depth at limit", nested(4), ""},
+		{"depth over limit", nested(5), "JSON nesting exceeds 4 levels"},
+		{"mixed nesting over limit", `{"a":[{"b":[{}]}]}`, "JSON nesting exceeds 4 levels"},
+		{"array at limit", array(3), ""},
+		{"array over limit", array(4), "JSON array or object exceeds 3 elements"},
+		{"object at limit", object(3), ""},
+		{"object over limit", object(4), "JSON array or object exceeds 3 elements"},
+		{"nested array over limit", `{"a":` + array(4) + `}`, "JSON array or object exceeds 3 elements"},
+		{"malformed left to decoder", `{"a":`, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := request.CheckLimits([]byte(tc.body), limits)
+			if tc.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			status, body := sharederrors.HTTPError(err)
+			require.Equal(t, http.StatusBadRequest, status)
+			require.Equal(t, tc.wantMsg, body["message"])
+		})
+	}
+}
+
+func TestCheckLimits_ZeroUsesDefaults(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, request.CheckLimits([]byte(nested(request.DefaultLimits.MaxDepth)), request.Limits{}))
+	require.Error(t, request.CheckLimits([]byte(nested(request.DefaultLimits.MaxDepth+1)), request.Limits{}))
+}
+
+func TestDecode_RejectsBeforeDecoding(t *testing.T) {
+	t.Parallel()
+
+	var dst any
+	err := request.Decode(strings.NewReader(nested(100000)), &dst, request.DefaultLimits)
+
+	require.Error(t, err)
+	require.Nil(t, dst, "the body must not be decoded")
+}
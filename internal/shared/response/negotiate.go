@@ -0,0 +1,65 @@
+// Package response writes handler responses in the representation the client
+// asked for.
+package response
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+// Format is a negotiated response representation.
+type Format int
+
+// Supported response formats. JSON is the default.
+const (
+	JSON Format = iota
+	XML
+)
+
+// Negotiate writes data with status as XML when the request's Accept header
+// prefers application/xml or text/xml over JSON, and as JSON otherwise. data
+// must carry xml struct tags to be rendered as XML. The response carries
+// Vary: Accept so shared caches keep the representations apart.
+//
+// nolint:wrapcheck // echo handlers return the response write error directly.
+func Negotiate(c *echo.Context, status int, data any) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	if Preferred(c.Request().Header.Get(echo.HeaderAccept)) == XML {
+		return c.XML(status, data)
+	}
+	return c.JSON(status, data)
+}
+
+// Preferred picks the response format for an Accept header value. XML wins
+// only with a strictly higher quality than JSON, so an empty header, */*, or
+// a tie all yield JSON.
+func Preferred(accept string) Format {
+	var jsonQ, xmlQ float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		switch mediaType {
+		case echo.MIMEApplicationXML, echo.MIMETextXML:
+			xmlQ = max(xmlQ, q)
+		case echo.MIMEApplicationJSON, "*/*", "application/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+
+	if xmlQ > jsonQ {
+		return XML
+	}
+	return JSON
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+package response_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/response"
+)
+
+type payload struct {
+	XMLName xml.Name `json:"-" xml:"payload"`
+	Name    string   `json:"name" xml:"name"`
+}
+
+func TestPreferred(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		want   response.Format
+	}{
+		{"", response.JSON},
+		{"*/*", response.JSON},
+		{"application/json", response.JSON},
+		{"application/xml", response.XML},
+		{"text/xml", response.XML},
+		{"application/xml, application/json", response.JSON},
+		{"application/json;q=0.5, application/xml", response.XML},
+		{"application/xml;q=0.9, */*;q=0.1", response.XML},
+		{"text/html", response.JSON},
+		{"application/xml;q=bogus", response.XML},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.accept, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, response.Preferred(tc.accept))
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	e := echo.New()
+	e.GET("/thing", func(c *echo.Context) error {
+		return response.Negotiate(c, http.StatusOK, payload{Name: "widget"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/thing", nil)
+	req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationXML)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationXML)
+	require.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary))
+	var got payload
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, "widget", got.Name)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/thing", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	require.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary))
+	require.JSONEq(t, `{"name":"widget"}`, rec.Body.String())
+}
@@ -0,0 +1,89 @@
+// Admin HTTP server construction and /admin routes.
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/zercle/zercle-go-template/internal/config"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+)
+
+// AdminHTTPName is the DI name of the admin *echo.Echo. It is only provided
+// when the admin listener is enabled.
+const AdminHTTPName = "http.admin"
+
+// logLevelRequest is the body accepted by PUT /admin/log-level.
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// NewAdminHTTP builds the *echo.Echo served on the admin listener. It hosts
+// the health probes, /metrics, /debug/pprof (when profiling is enabled), and
+// the /admin routes. It is meant to be bound to a private address only.
+func NewAdminHTTP(cfg *config.Config, logger *zerolog.Logger, registry *telemetry.Registry) *echo.Echo {
+	e := newEcho()
+	configureJSON(e, cfg)
+	e.Pre(normalizePath(cfg))
+
+	e.Use(middleware.Recover(logger))
+	e.Use(middleware.RequestID())
+	e.Use(middleware.ContextLogger(logger))
+	e.Use(middleware.JSONLimits(jsonLimits(cfg)))
+
+	registerHealthRoutes(e, cfg, registry)
+	registerDiagnosticsRoutes(e, cfg)
+
+	g := e.Group("/admin")
+	g.GET("/log-level", getLogLevelHandler())
+	g.PUT("/log-level", putLogLevelHandler())
+
+	return e
+}
+
+// getLogLevelHandler reports the current global log level.
+//
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func getLogLevelHandler() echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"level": zerolog.GlobalLevel().String()})
+	}
+}
+
+// putLogLevelHandler changes the global log level at runtime. Unknown levels
+// are rejected with 400 INVALID_INPUT.
+//
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func putLogLevelHandler() echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		var req logLevelRequest
+		if err := request.BindStrict(c, &req); err != nil {
+			status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+			return c.JSON(status, body)
+		}
+		if req.Level == "" {
+			status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+			return c.JSON(status, body)
+		}
+
+		level, err := zerolog.ParseLevel(req.Level)
+		if err != nil {
+			status, body := sharederrors.HTTPError(sharederrors.ErrInvalidInput)
+			return c.JSON(status, body)
+		}
+
+		previous := zerolog.GlobalLevel()
+		zerolog.SetGlobalLevel(level)
+		telemetry.LoggerFromContext(c.Request().Context()).Warn().
+			Str("from", previous.String()).
+			Str("to", level.String()).
+			Msg("log level changed")
+
+		return c.JSON(http.StatusOK, map[string]string{"level": level.String()})
+	}
+}
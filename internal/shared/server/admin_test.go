@@ -0,0 +1,171 @@
+//go:build unit
+
+package server_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+)
+
+func TestNewHTTP_AdminEnabledMovesDiagnostics(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.App.Environment = "development"
+	cfg.Admin.Enabled = true
+	logger := zerolog.New(nil)
+
+	public := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+	admin := server.NewAdminHTTP(cfg, &logger, telemetry.NewRegistry())
+
+	for _, path := range []string{"/metrics", "/debug/pprof/", "/admin/log-level"} {
+		rec := httptest.NewRecorder()
+		public.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusNotFound, rec.Code, "public %s", path)
+
+		rec = httptest.NewRecorder()
+		admin.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, "admin %s", path)
+	}
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		rec := httptest.NewRecorder()
+		public.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, "public %s", path)
+	}
+}
+
+func TestNewAdminHTTP_LogLevel(t *testing.T) {
+	previous := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previous) })
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	cfg := newTestConfig(t)
+	logger := zerolog.New(nil)
+	admin := server.NewAdminHTTP(cfg, &logger, telemetry.NewRegistry())
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"debug"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+	require.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"loud"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	req = httptest.NewRequest(http.MethodPut, "//admin/log-level/", strings.NewReader(`{"level":"info"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+}
+
+func TestApplication_AdminListener(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.HTTP.Host = "127.0.0.1"
+	cfg.GRPC.Host = "127.0.0.1"
+	cfg.Admin = config.AdminConfig{Enabled: true, Host: "127.0.0.1"}
+	logger := zerolog.New(nil)
+
+	injector := do.New()
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, &logger)
+	do.ProvideValue(injector, telemetry.NewRegistry())
+	require.NoError(t, server.Register(injector))
+
+	application := server.NewApplication(injector, cfg, &logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- application.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Error("application did not stop")
+		}
+	})
+
+	select {
+	case <-application.HasHTTPStarted():
+	case <-time.After(5 * time.Second):
+		t.Fatal("http server did not start")
+	}
+	require.Eventually(t, func() bool { return application.AdminAddr() != "" }, 5*time.Second, 10*time.Millisecond)
+
+	get := func(addr, path string) int {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, get(application.AdminAddr(), "/metrics"))
+	require.Equal(t, http.StatusOK, get(application.AdminAddr(), "/admin/log-level"))
+	require.Equal(t, http.StatusOK, get(application.AdminAddr(), "/healthz"))
+	require.Equal(t, http.StatusNotFound, get(application.HTTPAddr(), "/metrics"))
+	require.Equal(t, http.StatusNotFound, get(application.HTTPAddr(), "/admin/log-level"))
+	require.Equal(t, http.StatusOK, get(application.HTTPAddr(), "/healthz"))
+}
+
+func TestApplication_RunStopsAdminWhenGRPCFails(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = taken.Close() })
+
+	cfg := newTestConfig(t)
+	cfg.HTTP.Host = "127.0.0.1"
+	cfg.GRPC.Host = "127.0.0.1"
+	cfg.GRPC.Port = taken.Addr().(*net.TCPAddr).Port
+	cfg.Admin = config.AdminConfig{Enabled: true, Host: "127.0.0.1"}
+	logger := zerolog.New(nil)
+
+	injector := do.New()
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, &logger)
+	do.ProvideValue(injector, telemetry.NewRegistry())
+	require.NoError(t, server.Register(injector))
+
+	application := server.NewApplication(injector, cfg, &logger)
+
+	err = application.Run(context.Background())
+	require.ErrorContains(t, err, "start grpc")
+
+	adminAddr := application.AdminAddr()
+	require.NotEmpty(t, adminAddr)
+	conn, err := net.DialTimeout("tcp", adminAddr, time.Second)
+	if err == nil {
+		_ = conn.Close()
+	}
+	require.Error(t, err, "admin listener still accepting after Run returned")
+}
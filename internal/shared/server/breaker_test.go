@@ -0,0 +1,35 @@
+//go:build unit
+
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+	"github.com/zercle/zercle-go-template/pkg/breaker"
+)
+
+// TestRegister_MapsOpenCircuitTo503 verifies that an open circuit from any
+// breaker.Group surfaces as 503 once the shared servers are registered.
+func TestRegister_MapsOpenCircuitTo503(t *testing.T) {
+	logger := zerolog.New(nil)
+
+	injector := do.New()
+	do.ProvideValue(injector, newTestConfig(t))
+	do.ProvideValue(injector, &logger)
+	do.ProvideValue(injector, telemetry.NewRegistry())
+	require.NoError(t, server.Register(injector))
+
+	status, body := sharederrors.HTTPError(fmt.Errorf("send mail: %w", breaker.ErrCircuitOpen))
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "UNAVAILABLE", body["error"])
+}
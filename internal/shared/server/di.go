@@ -2,31 +2,79 @@
 package server
 
 import (
+	"fmt"
+
 	"github.com/labstack/echo/v5"
 	"github.com/rs/zerolog"
 	"github.com/samber/do/v2"
 	"google.golang.org/grpc"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+	"github.com/zercle/zercle-go-template/pkg/breaker"
+	"github.com/zercle/zercle-go-template/pkg/events"
+	"github.com/zercle/zercle-go-template/pkg/streaming"
 )
 
-// Register wires *echo.Echo, *grpc.Server, and the Application orchestrator
-// into the DI container. It depends on config, logger, telemetry providers,
-// and the health registry already being registered.
+// Register wires the shutdown *middleware.Drainer, the *streaming.Registry
+// that streaming handlers register with, the in-process *events.Bus,
+// *echo.Echo, *grpc.Server, the admin *echo.Echo (named AdminHTTPName, only
+// when the admin listener is enabled), and the Application orchestrator into
+// the DI container. It also maps breaker.ErrCircuitOpen to 503. It depends
+// on config, logger, telemetry providers, and the health registry already
+// being registered.
 //
 // Note: samber/do v2's Provide signature is `func Provide[T any](i Injector,
 // provider Provider[T])` and returns no error. Any construction failure
 // surfaces later via do.Invoke. We rely on the provider functions to
 // surface their own errors via Invoke.
 func Register(c do.Injector) error {
+	do.Provide(c, func(_ do.Injector) (*middleware.Drainer, error) {
+		return middleware.NewDrainer(), nil
+	})
+
+	do.Provide(c, func(_ do.Injector) (*streaming.Registry, error) {
+		return streaming.NewRegistry(), nil
+	})
+
+	// Clients that wrap their calls in a breaker.Group get 503 for an open
+	// circuit without registering the mapping themselves.
+	sharederrors.RegisterSentinel(breaker.ErrCircuitOpen, sharederrors.ErrUnavailable)
+
+	do.Provide(c, func(i do.Injector) (*events.Bus, error) {
+		logger := do.MustInvoke[*zerolog.Logger](i)
+		return events.New(events.WithOnPanic(func(topic string, recovered any) {
+			logger.Error().
+				Str("topic", topic).
+				Interface("panic", recovered).
+				Msg("event handler panicked")
+		})), nil
+	})
+
 	do.Provide(c, func(i do.Injector) (*echo.Echo, error) {
 		cfg := do.MustInvoke[*config.Config](i)
 		logger := do.MustInvoke[*zerolog.Logger](i)
 		registry := do.MustInvoke[*telemetry.Registry](i)
-		return NewHTTP(cfg, logger, registry), nil
+		drainer := do.MustInvoke[*middleware.Drainer](i)
+		return NewHTTP(cfg, logger, registry, drainer), nil
 	})
 
+	cfg, err := do.Invoke[*config.Config](c)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+
+	if cfg.Admin.Enabled {
+		do.ProvideNamed(c, AdminHTTPName, func(i do.Injector) (*echo.Echo, error) {
+			cfg := do.MustInvoke[*config.Config](i)
+			logger := do.MustInvoke[*zerolog.Logger](i)
+			registry := do.MustInvoke[*telemetry.Registry](i)
+			return NewAdminHTTP(cfg, logger, registry), nil
+		})
+	}
+
 	do.Provide(c, func(i do.Injector) (*grpc.Server, error) {
 		logger := do.MustInvoke[*zerolog.Logger](i)
 		return NewGRPC(logger), nil
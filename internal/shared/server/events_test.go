@@ -0,0 +1,49 @@
+//go:build unit
+
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+	"github.com/zercle/zercle-go-template/pkg/events"
+)
+
+type testEvent struct{}
+
+func (testEvent) Topic() string { return "test.event" }
+
+// TestRegister_EventBus verifies the container provides one event bus whose
+// handler panics are logged rather than crashing the process.
+func TestRegister_EventBus(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	injector := do.New()
+	do.ProvideValue(injector, newTestConfig(t))
+	do.ProvideValue(injector, &logger)
+	do.ProvideValue(injector, telemetry.NewRegistry())
+	require.NoError(t, server.Register(injector))
+
+	bus := do.MustInvoke[*events.Bus](injector)
+	require.Same(t, bus, do.MustInvoke[*events.Bus](injector))
+
+	events.SubscribeTo(bus, func(context.Context, testEvent) { panic("boom") })
+	require.NoError(t, bus.Publish(context.Background(), testEvent{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Close(ctx))
+
+	assert.Contains(t, buf.String(), `"topic":"test.event"`)
+	assert.Contains(t, buf.String(), "event handler panicked")
+}
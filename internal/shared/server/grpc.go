@@ -3,6 +3,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -52,6 +53,10 @@ func unaryInterceptor(logger *zerolog.Logger) grpc.UnaryServerInterceptor {
 					ev = logger.Warn()
 				}
 			}
+			// A client that hung up is routine, not worth a warning.
+			if errors.Is(ctx.Err(), context.Canceled) {
+				ev = logger.Debug()
+			}
 			ev.
 				Str("method", info.FullMethod).
 				Dur("latency", latency).
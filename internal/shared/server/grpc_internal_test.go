@@ -14,6 +14,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
 )
 
 func TestIsClientSideCode(t *testing.T) {
@@ -224,3 +226,23 @@ func TestStreamInterceptorPanicRecovery(t *testing.T) {
 		})
 	}
 }
+
+func TestUnaryInterceptorClientCanceledLoggedAtDebug(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	interceptor := unaryInterceptor(&logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	handler := func(ctx context.Context, _ any) (any, error) {
+		return nil, sharederrors.GRPCErr(ctx.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := interceptor(ctx, nil, info, handler)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	_, level := findPanicLine(t, &buf, "grpc request completed")
+	assert.Equal(t, "debug", level)
+}
@@ -3,8 +3,8 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"math"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -16,8 +16,12 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/buildinfo"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
 	"github.com/zercle/zercle-go-template/internal/shared/middleware"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+	"github.com/zercle/zercle-go-template/internal/shared/validation"
+	"github.com/zercle/zercle-go-template/pkg/logsample"
 )
 
 type echoValidator struct {
@@ -25,9 +29,18 @@ type echoValidator struct {
 }
 
 // Validate implements echo.Validator by delegating to go-playground/validator.
+// A failure is an INVALID_INPUT *AppError whose message joins the per-field
+// messages and whose details map each field to its message.
 func (cv *echoValidator) Validate(i any) error {
 	if err := cv.v.Struct(i); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return &sharederrors.AppError{
+			Code:       sharederrors.ErrInvalidInput.Code,
+			Message:    validation.Describe(err),
+			HTTPStatus: sharederrors.ErrInvalidInput.HTTPStatus,
+			GRPCCode:   sharederrors.ErrInvalidInput.GRPCCode,
+			Details:    validation.Details(err),
+			Cause:      err,
+		}
 	}
 	return nil
 }
@@ -39,40 +52,186 @@ func (cv *echoValidator) Validate(i any) error {
 const defaultProbeTimeout = 5 * time.Second
 
 // NewHTTP builds and returns an *echo.Echo with the standard middleware stack
-// and shared routes (/healthz, /readyz, /metrics).
-func NewHTTP(cfg *config.Config, logger *zerolog.Logger, registry *telemetry.Registry) *echo.Echo {
-	e := echo.New()
-	e.Validator = &echoValidator{v: validator.New()}
+// and shared routes (/healthz, /readyz, /version). Every response carries
+// the build version in X-App-Version. Unless the admin listener is enabled,
+// /metrics and (when profiling is enabled) /debug/pprof are served here too.
+// When strict JSON is enabled, request bodies with unknown fields fail to bind.
+// When compression is enabled, large responses are compressed with brotli
+// or gzip for clients that accept it. When allowed hosts are configured,
+// requests for any other Host are rejected with 400, except health probes.
+// Paths with repeated or trailing slashes are routed as their canonical form
+// (see normalizePath).
+// Once drainer is started, every request is rejected with 503 and
+// Connection: close.
+func NewHTTP(cfg *config.Config, logger *zerolog.Logger, registry *telemetry.Registry, drainer *middleware.Drainer) *echo.Echo {
+	e := newEcho()
+	e.IPExtractor = ipExtractor(cfg)
+	e.Validator = &echoValidator{v: validation.Validator()}
+	configureJSON(e, cfg)
+	e.Pre(normalizePath(cfg))
 
 	e.Use(middleware.Recover(logger))
 	e.Use(middleware.RequestID())
+	e.Use(middleware.VersionHeader(buildinfo.Get(cfg.App.Name).Version))
 	e.Use(middleware.OTel())
-	e.Use(middleware.AccessLog(logger))
+	e.Use(middleware.ContextLogger(logger))
+	e.Use(middleware.AccessLog(logger, middleware.WithSampler(logSampler(cfg))))
+	// Drain runs inside tracing and access logging so requests turned away
+	// during shutdown are still traced and logged.
+	e.Use(middleware.Drain(drainer))
+	e.Use(middleware.AllowedHosts(middleware.AllowedHostsConfig{
+		Hosts: cfg.HTTP.AllowedHosts,
+		Skip:  isHealthProbe,
+	}))
 	e.Use(middleware.CORS(cfg))
+	e.Use(middleware.JSONLimits(jsonLimits(cfg)))
 	if limit := parseBodyLimitBytes(cfg.HTTP.BodyLimit); limit > 0 {
 		e.Use(echomw.BodyLimit(limit))
 	}
+	if cfg.HTTP.CompressionEnabled {
+		e.Use(middleware.Compress(middleware.CompressConfig{
+			MinSize: cfg.HTTP.CompressionMinSize,
+			Level:   cfg.HTTP.CompressionLevel,
+			Skip:    skipCompression,
+		}))
+	}
+
+	registerHealthRoutes(e, cfg, registry)
+	if !cfg.Admin.Enabled {
+		registerDiagnosticsRoutes(e, cfg)
+	}
+
+	return e
+}
+
+// logSampler returns the access log sampler configured by LOG_SAMPLING_*, or
+// nil when sampling is disabled. Every level up to LOG_SAMPLING_LEVEL gets the
+// same rule.
+func logSampler(cfg *config.Config) *logsample.Sampler {
+	if !cfg.Log.SamplingEnabled {
+		return nil
+	}
+	maxLevel, err := zerolog.ParseLevel(cfg.Log.SamplingLevel)
+	if err != nil || maxLevel >= zerolog.ErrorLevel {
+		maxLevel = zerolog.InfoLevel
+	}
+	rule := logsample.Rule{First: cfg.Log.SamplingFirst, Thereafter: cfg.Log.SamplingThereafter}
+	rules := make(map[zerolog.Level]logsample.Rule)
+	for lvl := zerolog.TraceLevel; lvl <= maxLevel; lvl++ {
+		rules[lvl] = rule
+	}
+	return logsample.New(cfg.Log.SamplingWindow, rules)
+}
+
+// ipExtractor returns how c.RealIP finds the client address. Without trusted
+// proxies it is the connection's peer, so request headers cannot spoof it.
+// Otherwise X-Forwarded-For is walked back from the peer, past addresses in
+// the trusted ranges only, to the first address outside them.
+func ipExtractor(cfg *config.Config) echo.IPExtractor {
+	if len(cfg.HTTP.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range cfg.HTTP.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue // rejected by Config.Validate
+		}
+		opts = append(opts, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(opts...)
+}
 
+// skipCompression leaves the Prometheus scrape endpoint, which negotiates its
+// own encoding, and pprof, which serves gzipped profiles, to their handlers.
+func skipCompression(c *echo.Context) bool {
+	p := c.Request().URL.Path
+	return p == "/metrics" || strings.HasPrefix(p, "/debug/pprof")
+}
+
+// normalizePath returns the pre-routing middleware that canonicalizes
+// request paths. The pprof index is registered as /debug/pprof/ and is left
+// alone.
+func normalizePath(cfg *config.Config) echo.MiddlewareFunc {
+	return middleware.NormalizePath(middleware.NormalizePathConfig{
+		Redirect: cfg.HTTP.SlashRedirect,
+		Skip: func(c *echo.Context) bool {
+			return strings.HasPrefix(c.Request().URL.Path, pprofPrefix+"/")
+		},
+	})
+}
+
+// isHealthProbe reports whether the request is a liveness or readiness
+// probe, which orchestrators send to the pod IP rather than a public host.
+func isHealthProbe(c *echo.Context) bool {
+	p := c.Request().URL.Path
+	return p == "/healthz" || p == "/readyz"
+}
+
+// IsOperational reports whether the request is for a route this package
+// mounts for operators rather than clients: the health probes, /version,
+// /metrics, or /debug/pprof. Middleware that depends on other services, such
+// as feature flag evaluation, skips these so a slow dependency cannot fail a
+// liveness probe.
+func IsOperational(c *echo.Context) bool {
+	p := c.Request().URL.Path
+	return isHealthProbe(c) || p == "/version" || p == "/metrics" || strings.HasPrefix(p, pprofPrefix)
+}
+
+// registerHealthRoutes mounts the liveness and readiness probes and the build
+// metadata at /version.
+func registerHealthRoutes(e *echo.Echo, cfg *config.Config, registry *telemetry.Registry) {
 	probeTimeout := cfg.HTTP.HealthProbeTimeout
 	if probeTimeout <= 0 {
 		probeTimeout = defaultProbeTimeout
 	}
 
-	e.GET("/healthz", healthzHandler(registry, logger, probeTimeout))
-	e.GET("/readyz", readyzHandler(registry, logger, probeTimeout))
+	e.GET("/healthz", healthzHandler(registry, probeTimeout))
+	e.GET("/readyz", readyzHandler(registry, probeTimeout))
+	e.GET("/version", versionHandler(versionResponse{
+		Info:        buildinfo.Get(cfg.App.Name),
+		Environment: cfg.App.Environment,
+	}))
+}
+
+// versionResponse is the /version body: the build metadata and the
+// environment it is deployed to.
+type versionResponse struct {
+	buildinfo.Info
+	Environment string `json:"environment"`
+}
+
+// versionHandler reports which build is running.
+//
+// nolint:wrapcheck // echo handlers return the JSON write error directly.
+func versionHandler(resp versionResponse) echo.HandlerFunc {
+	return func(c *echo.Context) error {
+		return c.JSON(http.StatusOK, resp)
+	}
+}
+
+// registerDiagnosticsRoutes mounts /metrics and, when profiling is enabled,
+// /debug/pprof.
+func registerDiagnosticsRoutes(e *echo.Echo, cfg *config.Config) {
 	e.GET("/metrics", echo.WrapHandler(telemetry.MetricsHandler()))
 
-	return e
+	if cfg.ProfilingEnabled() {
+		registerPprof(e)
+	}
 }
 
 // healthzHandler returns the liveness handler. It returns 200 on success and
 // 500 only if the registry itself reports an unexpected error.
-func healthzHandler(registry *telemetry.Registry, logger *zerolog.Logger, probeTimeout time.Duration) echo.HandlerFunc {
+func healthzHandler(registry *telemetry.Registry, probeTimeout time.Duration) echo.HandlerFunc {
 	return func(c *echo.Context) error {
 		ctx, cancel := context.WithTimeout(c.Request().Context(), probeTimeout)
 		defer cancel()
 		if err := registry.Live(ctx); err != nil {
-			logger.Error().Err(err).Str("request_id", middleware.RequestIDFromContext(c)).Msg("liveness check failed")
+			telemetry.LoggerFromContext(ctx).Error().Err(err).Msg("liveness check failed")
 			return c.NoContent(http.StatusInternalServerError)
 		}
 		return c.NoContent(http.StatusOK)
@@ -82,12 +241,12 @@ func healthzHandler(registry *telemetry.Registry, logger *zerolog.Logger, probeT
 // readyzHandler returns the readiness handler. It returns 200 when all
 // readiness checkers pass and 503 with a generic body when any fail. The
 // detailed error is logged server-side but never returned to the caller.
-func readyzHandler(registry *telemetry.Registry, logger *zerolog.Logger, probeTimeout time.Duration) echo.HandlerFunc {
+func readyzHandler(registry *telemetry.Registry, probeTimeout time.Duration) echo.HandlerFunc {
 	return func(c *echo.Context) error {
 		ctx, cancel := context.WithTimeout(c.Request().Context(), probeTimeout)
 		defer cancel()
 		if err := registry.Ready(ctx); err != nil {
-			logger.Warn().Err(err).Str("request_id", middleware.RequestIDFromContext(c)).Msg("readiness check failed")
+			telemetry.LoggerFromContext(ctx).Warn().Err(err).Msg("readiness check failed")
 			return c.JSON(http.StatusServiceUnavailable, map[string]any{
 				"status": "not ready",
 			})
@@ -3,6 +3,8 @@
 package server_test
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -14,6 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/buildinfo"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
 	"github.com/zercle/zercle-go-template/internal/shared/server"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
 )
@@ -37,7 +42,7 @@ func TestNewHTTP_Healthz(t *testing.T) {
 	logger := zerolog.New(nil)
 	registry := telemetry.NewRegistry()
 
-	e := server.NewHTTP(cfg, &logger, registry)
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
 
 	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
 	rec := httptest.NewRecorder()
@@ -47,12 +52,39 @@ func TestNewHTTP_Healthz(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestNewHTTP_Version(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.App.Name = "version-test"
+	logger := zerolog.New(nil)
+	registry := telemetry.NewRegistry()
+
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body buildinfo.Info
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "version-test", body.Name)
+	require.NotEmpty(t, body.Version)
+	require.NotEmpty(t, body.Commit)
+	require.NotEmpty(t, body.BuildTime)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	require.Equal(t, cfg.App.Environment, raw["environment"])
+	require.Equal(t, body.Version, rec.Header().Get("X-App-Version"))
+}
+
 func TestNewHTTP_Readyz(t *testing.T) {
 	cfg := newTestConfig(t)
 	logger := zerolog.New(nil)
 	registry := telemetry.NewRegistry()
 
-	e := server.NewHTTP(cfg, &logger, registry)
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
 
 	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
 	rec := httptest.NewRecorder()
@@ -67,7 +99,7 @@ func TestNewHTTP_Metrics(t *testing.T) {
 	logger := zerolog.New(nil)
 	registry := telemetry.NewRegistry()
 
-	e := server.NewHTTP(cfg, &logger, registry)
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
 
 	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
 	rec := httptest.NewRecorder()
@@ -83,7 +115,7 @@ func TestNewHTTP_ValidatorRegistered(t *testing.T) {
 	logger := zerolog.New(nil)
 	registry := telemetry.NewRegistry()
 
-	e := server.NewHTTP(cfg, &logger, registry)
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
 
 	require.NotNil(t, e.Validator, "echo validator must be registered")
 }
@@ -93,7 +125,7 @@ func TestNewHTTP_ValidatorBinding(t *testing.T) {
 	logger := zerolog.New(nil)
 	registry := telemetry.NewRegistry()
 
-	e := server.NewHTTP(cfg, &logger, registry)
+	e := server.NewHTTP(cfg, &logger, registry, middleware.NewDrainer())
 	e.POST("/validate", func(c *echo.Context) error {
 		var req struct {
 			Name string `json:"name" validate:"required"`
@@ -102,7 +134,8 @@ func TestNewHTTP_ValidatorBinding(t *testing.T) {
 			return c.JSON(http.StatusBadRequest, map[string]any{"error": "bind"})
 		}
 		if err := c.Validate(req); err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]any{"error": "invalid"})
+			status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+			return c.JSON(status, body)
 		}
 		return c.JSON(http.StatusOK, req)
 	})
@@ -115,9 +148,228 @@ func TestNewHTTP_ValidatorBinding(t *testing.T) {
 
 	invalid := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"name":""}`))
 	invalid.Header.Set("Content-Type", "application/json")
+	invalid.Header.Set("X-Request-ID", "req-1")
 	rec = httptest.NewRecorder()
 	e.ServeHTTP(rec, invalid)
 	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.JSONEq(t, `{
+		"request_id": "req-1",
+		"error": "INVALID_INPUT",
+		"message": "name is a required field",
+		"details": {"name": "name is a required field"}
+	}`, rec.Body.String())
+}
+
+func TestNewHTTP_Pprof(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		enable      bool
+		wantStatus  int
+	}{
+		{"disabled outside development", "production", false, http.StatusNotFound},
+		{"enabled by flag", "production", true, http.StatusOK},
+		{"enabled in development", "development", false, http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			cfg.App.Environment = tc.environment
+			cfg.HTTP.EnableProfiling = tc.enable
+			logger := zerolog.New(nil)
+
+			e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+
+			for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/cmdline"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				rec := httptest.NewRecorder()
+
+				e.ServeHTTP(rec, req)
+
+				require.Equal(t, tc.wantStatus, rec.Code, path)
+			}
+		})
+	}
+}
+
+func TestNewHTTP_StrictJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		strict      bool
+		wantStatus  int
+		wantMessage string
+	}{
+		{"lenient by default", "production", false, http.StatusOK, ""},
+		{"strict by flag", "production", true, http.StatusBadRequest, `unknown field "nmae"`},
+		{"strict in development", "development", false, http.StatusBadRequest, `unknown field "nmae"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			cfg.App.Environment = tc.environment
+			cfg.HTTP.StrictJSON = tc.strict
+			logger := zerolog.New(nil)
+
+			e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+			e.POST("/things", func(c *echo.Context) error {
+				var req struct {
+					Name string `json:"name"`
+				}
+				if err := c.Bind(&req); err != nil {
+					status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+					return c.JSON(status, body)
+				}
+				return c.JSON(http.StatusOK, req)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"name":"ok","nmae":"typo"}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantMessage != "" {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Equal(t, "INVALID_INPUT", body["error"])
+				require.Equal(t, tc.wantMessage, body["message"])
+			}
+		})
+	}
+}
+
+func TestNewHTTP_StrictJSONMalformedBody(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.HTTP.StrictJSON = true
+	logger := zerolog.New(nil)
+
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+	e.POST("/things", func(c *echo.Context) error {
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&req); err != nil {
+			status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+			return c.JSON(status, body)
+		}
+		return c.JSON(http.StatusOK, req)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `"message":"invalid input"`)
+}
+
+func TestNewHTTP_RejectsDeeplyNestedJSON(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.HTTP.JSONMaxDepth = 16
+	logger := zerolog.New(nil)
+
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+	reached := false
+	e.POST("/things", func(c *echo.Context) error {
+		var req map[string]any
+		if err := c.Bind(&req); err != nil {
+			status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+			return c.JSON(status, body)
+		}
+		reached = true
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	payload := `{"a":` + strings.Repeat("[", 10000) + strings.Repeat("]", 10000) + `}`
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "JSON nesting exceeds 16 levels")
+	require.False(t, reached, "handler logic must not run")
+}
+
+func TestNewHTTP_RejectsRequestsWhileDraining(t *testing.T) {
+	cfg := newTestConfig(t)
+	logger := zerolog.New(nil)
+	drainer := middleware.NewDrainer()
+
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), drainer)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Shutdown has begun: a request arriving on a reused connection must be
+	// turned away and told to close it.
+	drainer.Start()
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "close", rec.Header().Get("Connection"))
+}
+
+func TestNewHTTP_LogsRequestsRejectedWhileDraining(t *testing.T) {
+	cfg := newTestConfig(t)
+	var buf strings.Builder
+	logger := zerolog.New(&buf)
+	drainer := middleware.NewDrainer()
+
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), drainer)
+	e.GET("/things", func(c *echo.Context) error { return c.NoContent(http.StatusNoContent) })
+	drainer.Start()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/things", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &entry))
+	require.Equal(t, "http request", entry["message"])
+	require.Equal(t, "/things", entry["path"])
+	require.EqualValues(t, http.StatusServiceUnavailable, entry["status"])
+	require.Equal(t, rec.Header().Get(echo.HeaderXRequestID), entry["request_id"])
+}
+
+func TestNewHTTP_RealIPTrustsOnlyConfiguredProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		remote  string
+		xff     string
+		want    string
+	}{
+		{"no proxies ignores header", nil, "203.0.113.7:1234", "198.51.100.1", "203.0.113.7"},
+		{"untrusted peer ignores header", []string{"10.0.0.0/8"}, "203.0.113.7:1234", "198.51.100.1", "203.0.113.7"},
+		{"trusted peer", []string{"10.0.0.0/8"}, "10.1.2.3:1234", "198.51.100.1", "198.51.100.1"},
+		{"spoofed prefix", []string{"10.0.0.0/8"}, "10.1.2.3:1234", "192.0.2.99, 198.51.100.1", "198.51.100.1"},
+		{"private peer not trusted by default", []string{"10.0.0.0/8"}, "192.168.1.5:1234", "198.51.100.1", "192.168.1.5"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			cfg.HTTP.TrustedProxies = tc.proxies
+			logger := zerolog.New(nil)
+			e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+			e.GET("/ip", func(c *echo.Context) error { return c.String(http.StatusOK, c.RealIP()) })
+
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			req.RemoteAddr = tc.remote
+			req.Header.Set(echo.HeaderXForwardedFor, tc.xff)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, tc.want, rec.Body.String())
+		})
+	}
 }
 
 func TestNewGRPC(t *testing.T) {
@@ -125,3 +377,107 @@ func TestNewGRPC(t *testing.T) {
 	gs := server.NewGRPC(&logger)
 	require.NotNil(t, gs)
 }
+
+func TestNewHTTP_ErrorBodiesCarryRequestID(t *testing.T) {
+	cfg := newTestConfig(t)
+	logger := zerolog.New(nil)
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+	e.POST("/validate", func(c *echo.Context) error {
+		var req struct {
+			Name string `json:"name" validate:"required"`
+		}
+		if err := c.Bind(&req); err != nil {
+			status, body := sharederrors.HTTPError(sharederrors.InvalidInput(err))
+			return c.JSON(status, body)
+		}
+		if err := c.Validate(req); err != nil {
+			status, body := sharederrors.HTTPError(err)
+			return c.JSON(status, body)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/fail", func(c *echo.Context) error {
+		status, body := sharederrors.HTTPError(errors.New("db down"))
+		return c.JSON(status, body)
+	})
+	e.GET("/panic", func(*echo.Context) error {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"validation", http.MethodPost, "/validate", `{}`, http.StatusBadRequest},
+		{"not found", http.MethodGet, "/missing", "", http.StatusNotFound},
+		{"internal", http.MethodGet, "/fail", "", http.StatusInternalServerError},
+		{"panic", http.MethodGet, "/panic", "", http.StatusInternalServerError},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			id := rec.Header().Get("X-Request-ID")
+			require.NotEmpty(t, id)
+			require.Equal(t, id, body["request_id"])
+		})
+	}
+}
+
+func TestNewHTTP_NormalizesPaths(t *testing.T) {
+	for _, redirect := range []bool{false, true} {
+		cfg := newTestConfig(t)
+		cfg.HTTP.SlashRedirect = redirect
+		logger := zerolog.New(nil)
+		e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+		e.POST("/api/v1/items", func(c *echo.Context) error {
+			return c.NoContent(http.StatusCreated)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "//version/", nil))
+		if redirect {
+			require.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			require.Equal(t, "/version", rec.Header().Get("Location"))
+		} else {
+			require.Equal(t, http.StatusOK, rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api//v1/items/", strings.NewReader(`{}`)))
+		require.Equal(t, http.StatusCreated, rec.Code)
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/missing/", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, "NOT_FOUND", body["error"])
+	}
+}
+
+func TestIsOperational(t *testing.T) {
+	e := echo.New()
+	for path, want := range map[string]bool{
+		"/healthz":             true,
+		"/readyz":              true,
+		"/version":             true,
+		"/metrics":             true,
+		"/debug/pprof/heap":    true,
+		"/api/v1/items":        false,
+		"/versions":            false,
+		"/admin/feature-flags": false,
+	} {
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, path, nil), httptest.NewRecorder())
+		require.Equal(t, want, server.IsOperational(c), path)
+	}
+}
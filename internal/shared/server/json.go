@@ -0,0 +1,62 @@
+// Guarded JSON request decoding and error body serialization.
+package server
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/labstack/echo/v5"
+
+	"github.com/zercle/zercle-go-template/internal/config"
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/request"
+)
+
+// jsonSerializer is the echo.JSONSerializer behind c.Bind and c.JSON. It
+// checks request bodies against the configured request.Limits before
+// decoding and, in strict mode, rejects fields the target type does not
+// declare, so a typo such as "nmae" fails loudly instead of silently binding
+// a zero value. Error bodies get the request ID so a client can quote it
+// when reporting the failure.
+type jsonSerializer struct {
+	echo.DefaultJSONSerializer
+	strict bool
+}
+
+// Deserialize decodes the request body into target with request.DecodeStrict
+// or request.Decode.
+func (s jsonSerializer) Deserialize(c *echo.Context, target any) error {
+	decode := request.Decode
+	if s.strict {
+		decode = request.DecodeStrict
+	}
+	if err := decode(c.Request().Body, target, request.LimitsFrom(c)); err != nil {
+		return fmt.Errorf("deserialize: %w", err)
+	}
+	return nil
+}
+
+// Serialize encodes i, adding "request_id" to a sharederrors.ErrorBody when
+// the request has one.
+func (s jsonSerializer) Serialize(c *echo.Context, i any, indent string) error {
+	if body, ok := i.(sharederrors.ErrorBody); ok {
+		if id := middleware.RequestIDFromContext(c); id != "" {
+			body = maps.Clone(body)
+			body["request_id"] = id
+			i = body
+		}
+	}
+	return s.DefaultJSONSerializer.Serialize(c, i, indent) //nolint:wrapcheck // echo writes the response.
+}
+
+// configureJSON installs jsonSerializer on e, decoding in strict mode when
+// cfg.StrictJSONEnabled reports true.
+func configureJSON(e *echo.Echo, cfg *config.Config) {
+	e.JSONSerializer = jsonSerializer{strict: cfg.StrictJSONEnabled()}
+}
+
+// jsonLimits returns the request body limits configured for cfg.
+func jsonLimits(cfg *config.Config) request.Limits {
+	return request.Limits{MaxDepth: cfg.HTTP.JSONMaxDepth, MaxElements: cfg.HTTP.JSONMaxElements}
+}
@@ -0,0 +1,27 @@
+// net/http/pprof route registration.
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/labstack/echo/v5"
+)
+
+// pprofPrefix is the path the pprof handlers expect to be mounted under;
+// pprof.Index derives the profile name from the remainder of the path.
+const pprofPrefix = "/debug/pprof"
+
+// registerPprof mounts the net/http/pprof handlers under /debug/pprof. The
+// package is imported for its handlers only; nothing is registered on
+// http.DefaultServeMux by this server.
+func registerPprof(e *echo.Echo) {
+	e.GET(pprofPrefix+"/cmdline", echo.WrapHandler(http.HandlerFunc(pprof.Cmdline)))
+	e.GET(pprofPrefix+"/profile", echo.WrapHandler(http.HandlerFunc(pprof.Profile)))
+	e.GET(pprofPrefix+"/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	e.POST(pprofPrefix+"/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	e.GET(pprofPrefix+"/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace)))
+	// Index serves the listing at /debug/pprof/ and named profiles such as
+	// heap, goroutine, and allocs below it.
+	e.GET(pprofPrefix+"/*", echo.WrapHandler(http.HandlerFunc(pprof.Index)))
+}
@@ -0,0 +1,106 @@
+// Routing and error rendering shared by the public and admin HTTP servers.
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+
+	sharederrors "github.com/zercle/zercle-go-template/internal/shared/errors"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+)
+
+// newEcho returns an *echo.Echo whose GET routes also answer HEAD and whose
+// unmatched requests get the shared error body.
+func newEcho() *echo.Echo {
+	return echo.NewWithConfig(echo.Config{
+		Router:           headRouter{echo.NewRouter(echo.RouterConfig{})},
+		HTTPErrorHandler: httpErrorHandler,
+	})
+}
+
+// headRouter serves HEAD with the GET route on paths that register GET but
+// not HEAD, and lists HEAD in the Allow header those paths send with OPTIONS
+// and 405 responses. net/http drops the body of HEAD responses and keeps
+// the headers the GET handler sets, including Content-Length for short
+// bodies.
+type headRouter struct {
+	*echo.DefaultRouter
+}
+
+// Route implements echo.Router.
+func (r headRouter) Route(c *echo.Context) echo.HandlerFunc {
+	h := r.DefaultRouter.Route(c)
+
+	// The router sets the allow list only when the path matched but the
+	// method did not.
+	allow, ok := c.Get(echo.ContextKeyHeaderAllow).(string)
+	if !ok || !allowsMethod(allow, http.MethodGet) || allowsMethod(allow, http.MethodHead) {
+		return h
+	}
+	c.Set(echo.ContextKeyHeaderAllow, allow+", "+http.MethodHead)
+
+	req := c.Request()
+	if req.Method != http.MethodHead {
+		return h
+	}
+	req.Method = http.MethodGet
+	h = r.DefaultRouter.Route(c)
+	req.Method = http.MethodHead
+	return h
+}
+
+// allowsMethod reports whether the comma-separated allow list contains
+// method.
+func allowsMethod(allow, method string) bool {
+	for m := range strings.SplitSeq(allow, ",") {
+		if strings.TrimSpace(m) == method {
+			return true
+		}
+	}
+	return false
+}
+
+// httpErrorHandler renders the errors that reach echo, such as unmatched
+// routes, 405s, and errors returned by middleware, in the shared error body.
+// HEAD requests get the status and headers only. The Allow header set for a
+// 405 is kept.
+func httpErrorHandler(c *echo.Context, err error) {
+	if r, _ := echo.UnwrapResponse(c.Response()); r != nil && r.Committed {
+		return
+	}
+
+	status, body := errorResponse(err)
+	var werr error
+	if c.Request().Method == http.MethodHead {
+		werr = c.NoContent(status)
+	} else {
+		werr = c.JSON(status, body)
+	}
+	if werr != nil {
+		telemetry.LoggerFromContext(c.Request().Context()).Debug().Err(werr).Msg("write error response")
+	}
+}
+
+// errorResponse maps echo's status errors to a code derived from the status
+// text (405 -> METHOD_NOT_ALLOWED) and everything else through
+// sharederrors.HTTPError.
+func errorResponse(err error) (int, sharederrors.ErrorBody) {
+	var app *sharederrors.AppError
+	var coder echo.HTTPStatusCoder
+	if errors.As(err, &app) || !errors.As(err, &coder) || coder.StatusCode() == 0 {
+		return sharederrors.HTTPError(err)
+	}
+
+	status := coder.StatusCode()
+	if status == http.StatusNotFound {
+		return sharederrors.HTTPError(sharederrors.ErrNotFound)
+	}
+	text := http.StatusText(status)
+	return status, sharederrors.ErrorBody{
+		"error":   strings.ToUpper(strings.ReplaceAll(text, " ", "_")),
+		"message": strings.ToLower(text),
+	}
+}
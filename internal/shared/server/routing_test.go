@@ -0,0 +1,125 @@
+//go:build unit
+
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+)
+
+func newRoutingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	cfg := newTestConfig(t)
+	cfg.HTTP.CompressionEnabled = false
+	logger := zerolog.New(nil)
+	e := server.NewHTTP(cfg, &logger, telemetry.NewRegistry(), middleware.NewDrainer())
+	e.GET("/items", func(c *echo.Context) error {
+		c.Response().Header().Set("X-Total-Count", "2")
+		return c.JSON(http.StatusOK, []string{"a", "b"})
+	})
+	e.POST("/items", func(c *echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+	e.PUT("/items/:id", func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doRequest(t *testing.T, method, url string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestRouting_HeadMirrorsGet(t *testing.T) {
+	srv := newRoutingServer(t)
+
+	get, getBody := doRequest(t, http.MethodGet, srv.URL+"/items")
+	require.Equal(t, http.StatusOK, get.StatusCode)
+	require.NotEmpty(t, getBody)
+
+	head, headBody := doRequest(t, http.MethodHead, srv.URL+"/items")
+	require.Equal(t, http.StatusOK, head.StatusCode)
+	assert.Empty(t, headBody)
+	for _, h := range []string{"Content-Type", "Content-Length", "X-Total-Count"} {
+		assert.Equal(t, get.Header.Get(h), head.Header.Get(h), h)
+	}
+
+	probe, probeBody := doRequest(t, http.MethodHead, srv.URL+"/healthz")
+	assert.Equal(t, http.StatusOK, probe.StatusCode)
+	assert.Empty(t, probeBody)
+}
+
+func TestRouting_OptionsListsRegisteredMethods(t *testing.T) {
+	srv := newRoutingServer(t)
+
+	resp, body := doRequest(t, http.MethodOptions, srv.URL+"/items")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, body)
+	assert.ElementsMatch(t, []string{"OPTIONS", "GET", "HEAD", "POST"}, splitAllow(resp.Header.Get("Allow")))
+
+	resp, _ = doRequest(t, http.MethodOptions, srv.URL+"/items/42")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.ElementsMatch(t, []string{"OPTIONS", "PUT"}, splitAllow(resp.Header.Get("Allow")))
+}
+
+func TestRouting_MethodNotAllowed(t *testing.T) {
+	srv := newRoutingServer(t)
+
+	resp, body := doRequest(t, http.MethodDelete, srv.URL+"/items")
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.ElementsMatch(t, []string{"OPTIONS", "GET", "HEAD", "POST"}, splitAllow(resp.Header.Get("Allow")))
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "METHOD_NOT_ALLOWED", got["error"])
+	assert.Equal(t, "method not allowed", got["message"])
+
+	// A path without GET does not gain HEAD.
+	resp, body = doRequest(t, http.MethodHead, srv.URL+"/items/42")
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Empty(t, body)
+	assert.ElementsMatch(t, []string{"OPTIONS", "PUT"}, splitAllow(resp.Header.Get("Allow")))
+}
+
+func TestRouting_NotFound(t *testing.T) {
+	srv := newRoutingServer(t)
+
+	resp, body := doRequest(t, http.MethodGet, srv.URL+"/nope")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "NOT_FOUND", got["error"])
+}
+
+func splitAllow(allow string) []string {
+	var methods []string
+	for m := range strings.SplitSeq(allow, ",") {
+		methods = append(methods, strings.TrimSpace(m))
+	}
+	return methods
+}
@@ -1,9 +1,10 @@
-// Application orchestrates starting and gracefully shutting down HTTP, gRPC,
-// database (gorm), Valkey, and telemetry providers.
+// Application orchestrates starting and gracefully shutting down HTTP, admin
+// HTTP, gRPC, database (gorm), Valkey, and telemetry providers.
 package server
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/rs/zerolog"
@@ -23,8 +25,20 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/middleware"
+	"github.com/zercle/zercle-go-template/pkg/events"
+	"github.com/zercle/zercle-go-template/pkg/streaming"
 )
 
+// streamCloseTimeout bounds how long shutdown waits for streaming handlers to
+// return after their contexts are canceled, so a handler that ignores
+// cancellation cannot consume the whole shutdown budget.
+const streamCloseTimeout = 5 * time.Second
+
+// eventDrainTimeout bounds how long shutdown waits for event subscribers to
+// handle already-published events before the database is closed.
+const eventDrainTimeout = 5 * time.Second
+
 // Application holds the runtime components required to start and stop the
 // service. It is constructed from a populated DI container and keeps the
 // orchestration logic separate from the wiring code.
@@ -32,6 +46,7 @@ type Application struct {
 	cfg             *config.Config
 	logger          *zerolog.Logger
 	httpServer      *echo.Echo
+	httpStdServer   *http.Server
 	httpListener    net.Addr
 	httpStartCtx    context.Context
 	httpStartCancel context.CancelFunc
@@ -39,6 +54,10 @@ type Application struct {
 	httpStartErr    error
 	grpcServer      *grpc.Server
 	grpcListener    net.Listener
+	adminListener   net.Addr
+	adminCancel     context.CancelFunc
+	adminStopped    chan struct{}
+	adminErr        error
 	injector        do.Injector
 	startMu         sync.Mutex
 	httpStarted     chan struct{}
@@ -49,11 +68,12 @@ type Application struct {
 // look up infrastructure dependencies at runtime inside Run.
 func NewApplication(injector do.Injector, cfg *config.Config, logger *zerolog.Logger) *Application {
 	return &Application{
-		cfg:         cfg,
-		logger:      logger,
-		injector:    injector,
-		httpStarted: make(chan struct{}),
-		httpStopped: make(chan struct{}),
+		cfg:          cfg,
+		logger:       logger,
+		injector:     injector,
+		httpStarted:  make(chan struct{}),
+		httpStopped:  make(chan struct{}),
+		adminStopped: make(chan struct{}),
 	}
 }
 
@@ -87,6 +107,19 @@ func (a *Application) HTTPAddr() string {
 	return a.httpListener.String()
 }
 
+// AdminAddr returns the bound admin listener address after Run() has started
+// the admin server. It returns an empty string when the admin listener is
+// disabled or not yet started.
+func (a *Application) AdminAddr() string {
+	a.startMu.Lock()
+	defer a.startMu.Unlock()
+
+	if a.adminListener == nil {
+		return ""
+	}
+	return a.adminListener.String()
+}
+
 // HasHTTPStarted returns a channel that is closed once the HTTP listener has
 // been bound. Callers can use it to wait for the server to be ready.
 func (a *Application) HasHTTPStarted() <-chan struct{} {
@@ -98,8 +131,10 @@ func (a *Application) Logger() *zerolog.Logger {
 	return a.logger
 }
 
-// Run starts the HTTP and gRPC servers and blocks until a signal or a server
-// error occurs, then performs an ordered graceful shutdown.
+// Run starts the HTTP, admin (when enabled), and gRPC servers and blocks
+// until a signal or a server error occurs, then performs an ordered graceful
+// shutdown. If a server fails to start, the ones already started are stopped
+// before Run returns.
 func (a *Application) Run(ctx context.Context) error {
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -108,7 +143,13 @@ func (a *Application) Run(ctx context.Context) error {
 		return fmt.Errorf("start http: %w", err)
 	}
 
+	if err := a.startAdmin(ctx); err != nil {
+		a.abortStart(ctx)
+		return fmt.Errorf("start admin: %w", err)
+	}
+
 	if err := a.startGRPC(); err != nil {
+		a.abortStart(ctx)
 		return fmt.Errorf("start grpc: %w", err)
 	}
 
@@ -159,6 +200,9 @@ func (a *Application) StartHTTP(ctx context.Context) error {
 				s.ReadTimeout = a.cfg.HTTP.ReadTimeout
 				s.WriteTimeout = a.cfg.HTTP.WriteTimeout
 				s.IdleTimeout = a.cfg.HTTP.IdleTimeout
+				a.startMu.Lock()
+				a.httpStdServer = s
+				a.startMu.Unlock()
 				return nil
 			},
 			ListenerAddrFunc: func(addr net.Addr) {
@@ -182,6 +226,56 @@ func (a *Application) StartHTTP(ctx context.Context) error {
 	return nil
 }
 
+// startAdmin resolves the admin HTTP server and binds its listener when the
+// admin listener is enabled. Binding happens synchronously so a port conflict
+// fails Run immediately; serving continues in the background until ctx or
+// shutdownAdmin cancels it.
+func (a *Application) startAdmin(ctx context.Context) error {
+	if !a.cfg.Admin.Enabled {
+		return nil
+	}
+
+	admin, err := do.InvokeNamed[*echo.Echo](a.injector, AdminHTTPName)
+	if err != nil {
+		return fmt.Errorf("resolve admin http server: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", a.cfg.AdminAddr())
+	if err != nil {
+		return fmt.Errorf("listen admin %s: %w", a.cfg.AdminAddr(), err)
+	}
+
+	adminCtx, cancel := context.WithCancel(ctx)
+	a.startMu.Lock()
+	a.adminListener = listener.Addr()
+	a.adminCancel = cancel
+	a.startMu.Unlock()
+
+	go func() {
+		defer close(a.adminStopped)
+		sc := echo.StartConfig{
+			Listener:        listener,
+			HideBanner:      true,
+			HidePort:        true,
+			GracefulTimeout: a.cfg.App.ShutdownTimeout,
+			BeforeServeFunc: func(s *http.Server) error {
+				s.ReadTimeout = a.cfg.HTTP.ReadTimeout
+				s.WriteTimeout = a.cfg.HTTP.WriteTimeout
+				s.IdleTimeout = a.cfg.HTTP.IdleTimeout
+				return nil
+			},
+		}
+		if err := sc.Start(adminCtx, admin); err != nil {
+			a.logger.Error().Err(err).Msg("admin http server stopped")
+			a.startMu.Lock()
+			a.adminErr = err
+			a.startMu.Unlock()
+		}
+	}()
+
+	return nil
+}
+
 // startGRPC resolves the shared gRPC server from the DI container and binds the
 // listener. HTTP must be started before or alongside this call.
 func (a *Application) startGRPC() error {
@@ -201,10 +295,24 @@ func (a *Application) startGRPC() error {
 	return nil
 }
 
-// serverErrorChannel launches both servers and returns a channel that receives
-// the first fatal error from either.
+// abortStart stops the HTTP and admin servers that Run started before a later
+// server failed to start, so their listeners are closed when Run returns.
+func (a *Application) abortStart(ctx context.Context) {
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.shutdownHTTP(shutdownCtx); err != nil {
+		a.logger.Error().Err(err).Msg("http shutdown error")
+	}
+	if err := a.shutdownAdmin(shutdownCtx); err != nil {
+		a.logger.Error().Err(err).Msg("admin http shutdown error")
+	}
+}
+
+// serverErrorChannel launches the servers and returns a channel that receives
+// the first fatal error from any of them.
 func (a *Application) serverErrorChannel() <-chan error {
-	errCh := make(chan error, 2)
+	errCh := make(chan error, 3)
 
 	go func() {
 		errCh <- a.runHTTPServer()
@@ -212,6 +320,11 @@ func (a *Application) serverErrorChannel() <-chan error {
 	go func() {
 		errCh <- a.grpcServer.Serve(a.grpcListener)
 	}()
+	if a.cfg.Admin.Enabled {
+		go func() {
+			errCh <- a.runAdminServer()
+		}()
+	}
 
 	return errCh
 }
@@ -228,6 +341,15 @@ func (a *Application) runHTTPServer() error {
 	return nil
 }
 
+// runAdminServer blocks until the admin server stops and returns the error it
+// stopped with, if any.
+func (a *Application) runAdminServer() error {
+	<-a.adminStopped
+	a.startMu.Lock()
+	defer a.startMu.Unlock()
+	return a.adminErr
+}
+
 // shutdown performs the ordered graceful shutdown sequence using a fresh,
 // timeout-bounded context derived from ctx so an already-cancelled signal
 // context does not starve the per-component shutdown calls.
@@ -250,8 +372,14 @@ func (a *Application) shutdown(ctx context.Context) {
 		a.grpcServer.Stop()
 	}
 
+	if err := a.shutdownAdmin(shutdownCtx); err != nil {
+		a.logger.Error().Err(err).Msg("admin http shutdown error")
+	}
+
+	a.closeEvents(shutdownCtx)
+
 	if db, ok := a.invokeDB(); ok {
-		a.closeDB(db)
+		a.closeDB(shutdownCtx, db)
 	}
 
 	if client, ok := a.invokeValkey(); ok {
@@ -273,14 +401,20 @@ func (a *Application) shutdown(ctx context.Context) {
 	a.logger.Info().Msg("shutdown complete")
 }
 
-// shutdownHTTP stops the echo HTTP server gracefully. It cancels the start
-// context, which signals echo to begin its internal graceful drain, and then
-// waits for the HTTP goroutine to actually finish (bounded by ctx) so that
-// the gorm db and Valkey are not closed underneath in-flight requests.
+// shutdownHTTP stops the echo HTTP server gracefully. It first marks the
+// server as draining, so requests still arriving on open keep-alive
+// connections get 503 with Connection: close, and disables keep-alives. Next
+// it ends registered streams, which would otherwise hold the drain open until
+// the timeout. It then cancels the start context, which signals echo to begin
+// its internal graceful drain, and waits for the HTTP goroutine to actually
+// finish (bounded by ctx) so that the gorm db and Valkey are not closed
+// underneath in-flight requests.
 func (a *Application) shutdownHTTP(ctx context.Context) error {
 	if a.httpStartCancel == nil {
 		return nil
 	}
+	a.beginDrain()
+	a.closeStreams(ctx)
 	a.httpStartCancel()
 	select {
 	case <-a.httpStopped:
@@ -290,6 +424,77 @@ func (a *Application) shutdownHTTP(ctx context.Context) error {
 	return nil
 }
 
+// beginDrain flips the shared Drainer and disables HTTP keep-alives, which
+// also closes currently idle connections.
+func (a *Application) beginDrain() {
+	if drainer, err := do.Invoke[*middleware.Drainer](a.injector); err == nil {
+		drainer.Start()
+	} else if !errors.Is(err, do.ErrServiceNotFound) {
+		a.logger.Warn().Err(err).Msg("drainer not available")
+	}
+
+	a.startMu.Lock()
+	srv := a.httpStdServer
+	a.startMu.Unlock()
+	if srv != nil {
+		srv.SetKeepAlivesEnabled(false)
+	}
+}
+
+// closeStreams cancels every registered stream and waits, at most
+// streamCloseTimeout, for the streaming handlers to return.
+func (a *Application) closeStreams(ctx context.Context) {
+	registry, err := do.Invoke[*streaming.Registry](a.injector)
+	if err != nil {
+		if !errors.Is(err, do.ErrServiceNotFound) {
+			a.logger.Warn().Err(err).Msg("stream registry not available")
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, streamCloseTimeout)
+	defer cancel()
+	if err := registry.Shutdown(ctx); err != nil {
+		a.logger.Warn().Err(err).Msg("streams did not close in time")
+	}
+}
+
+// closeEvents closes the event bus once no request can publish any more,
+// waiting at most eventDrainTimeout for subscribers to handle what is queued.
+func (a *Application) closeEvents(ctx context.Context) {
+	bus, err := do.Invoke[*events.Bus](a.injector)
+	if err != nil {
+		if !errors.Is(err, do.ErrServiceNotFound) {
+			a.logger.Warn().Err(err).Msg("event bus not available")
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, eventDrainTimeout)
+	defer cancel()
+	if err := bus.Close(ctx); err != nil {
+		a.logger.Warn().Err(err).Uint64("dropped", bus.Dropped()).Msg("event subscribers did not drain in time")
+	}
+}
+
+// shutdownAdmin stops the admin HTTP server after the public servers have
+// drained, so metrics and probes stay reachable for as long as possible.
+func (a *Application) shutdownAdmin(ctx context.Context) error {
+	a.startMu.Lock()
+	cancel := a.adminCancel
+	a.startMu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-a.adminStopped:
+	case <-ctx.Done():
+		return fmt.Errorf("admin http shutdown timed out: %w", ctx.Err())
+	}
+	return nil
+}
+
 // invokeDB looks up the *gorm.DB from the DI container and reports whether
 // it was found. A missing provider is treated as "not configured" and is
 // skipped silently.
@@ -306,14 +511,36 @@ func (a *Application) invokeDB() (*gorm.DB, bool) {
 
 // closeDB closes the underlying *sql.DB of a *gorm.DB and logs any error.
 // *gorm.DB itself does not expose Close; the database/sql handle does.
-func (a *Application) closeDB(db *gorm.DB) {
+func (a *Application) closeDB(ctx context.Context, db *gorm.DB) {
 	sqlDB, err := db.DB()
 	if err != nil {
 		a.logger.Warn().Err(err).Msg("gorm db sql handle unavailable")
 		return
 	}
-	if err := sqlDB.Close(); err != nil {
-		a.logger.Warn().Err(err).Msg("gorm db close error")
+	a.closePool(ctx, sqlDB)
+}
+
+// dbPool is the part of *sql.DB that closePool needs.
+type dbPool interface {
+	Close() error
+	Stats() sql.DBStats
+}
+
+// closePool closes pool but stops waiting when ctx ends. database/sql's Close
+// waits for queries already running on the server, so a stuck query would
+// otherwise hold shutdown open; on timeout the close is left to finish in the
+// background and the connections still in use are logged.
+func (a *Application) closePool(ctx context.Context, pool dbPool) {
+	done := make(chan error, 1)
+	go func() { done <- pool.Close() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			a.logger.Warn().Err(err).Msg("gorm db close error")
+		}
+	case <-ctx.Done():
+		a.logger.Warn().Err(ctx.Err()).Int("in_use", pool.Stats().InUse).Msg("gorm db close timed out")
 	}
 }
 
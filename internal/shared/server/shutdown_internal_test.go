@@ -0,0 +1,62 @@
+//go:build unit
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// stuckPool never finishes closing until release is closed.
+type stuckPool struct {
+	release chan struct{}
+	inUse   int
+}
+
+func (p *stuckPool) Close() error {
+	<-p.release
+	return nil
+}
+
+func (p *stuckPool) Stats() sql.DBStats { return sql.DBStats{InUse: p.inUse} }
+
+func TestClosePool(t *testing.T) {
+	t.Run("closed in time", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := zerolog.New(&buf)
+		a := &Application{logger: &logger}
+		pool := &stuckPool{release: make(chan struct{})}
+		close(pool.release)
+
+		a.closePool(t.Context(), pool)
+
+		require.Empty(t, buf.String())
+	})
+
+	t.Run("warns on timeout", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := zerolog.New(&buf)
+		a := &Application{logger: &logger}
+		pool := &stuckPool{release: make(chan struct{}), inUse: 2}
+		t.Cleanup(func() { close(pool.release) })
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		a.closePool(ctx, pool)
+
+		require.Less(t, time.Since(start), time.Second)
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		require.Equal(t, "warn", entry["level"])
+		require.Equal(t, "gorm db close timed out", entry["message"])
+		require.EqualValues(t, 2, entry["in_use"])
+	})
+}
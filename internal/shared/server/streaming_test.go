@@ -0,0 +1,100 @@
+//go:build unit
+
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/server"
+	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
+	"github.com/zercle/zercle-go-template/pkg/streaming"
+)
+
+// TestApplication_ShutdownClosesStreams verifies that an open SSE stream is
+// told to close and ends promptly on shutdown instead of holding the HTTP
+// drain open for the whole shutdown timeout.
+func TestApplication_ShutdownClosesStreams(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.App.ShutdownTimeout = 30 * time.Second
+	cfg.HTTP.Host = "127.0.0.1"
+	cfg.GRPC.Host = "127.0.0.1"
+	logger := zerolog.New(nil)
+
+	injector := do.New()
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, &logger)
+	do.ProvideValue(injector, telemetry.NewRegistry())
+	require.NoError(t, server.Register(injector))
+
+	registry := do.MustInvoke[*streaming.Registry](injector)
+	application := server.NewApplication(injector, cfg, &logger)
+	application.Echo().GET("/events", func(c *echo.Context) error {
+		ctx, id := registry.RegisterStream(c.Request().Context())
+		defer registry.UnregisterStream(id)
+
+		c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+		if err := streaming.WriteEvent(c.Response(), "hello", "{}"); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		if streaming.IsShutdown(ctx) {
+			return streaming.WriteEvent(c.Response(), streaming.ShutdownEvent, "{}")
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- application.Run(ctx) }()
+
+	select {
+	case <-application.HasHTTPStarted():
+	case <-time.After(5 * time.Second):
+		t.Fatal("http server did not start")
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://"+application.HTTPAddr()+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	events := make(chan string, 4)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				events <- name
+			}
+		}
+	}()
+	require.Equal(t, "hello", <-events)
+	require.Eventually(t, func() bool { return registry.Active() == 1 }, time.Second, 5*time.Millisecond)
+
+	start := time.Now()
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("application did not stop")
+	}
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	require.Equal(t, streaming.ShutdownEvent, <-events)
+	_, open := <-events
+	require.False(t, open, "stream should be closed")
+}
@@ -3,33 +3,90 @@
 package telemetry
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/buildinfo"
 )
 
 // NewLogger builds a zerolog.Logger from configuration, sets the global level,
 // and returns the configured logger. The logger writes JSON to stdout by default;
 // switch to a human-readable console format when cfg.Log.Format is "console".
 func NewLogger(cfg *config.Config) (*zerolog.Logger, error) {
+	return NewLoggerTo(cfg, os.Stdout)
+}
+
+// NewLoggerTo is NewLogger writing to w. The console format is colorized in
+// development only. Timestamps follow cfg.Log.TimeFormat, which like the
+// level is process-wide in zerolog, and cfg.Log.IncludeCaller adds a
+// file:line "caller" field. Every line carries the build's "version". The
+// logger also becomes the fallback returned by LoggerFromContext.
+func NewLoggerTo(cfg *config.Config, w io.Writer) (*zerolog.Logger, error) {
 	level, err := zerolog.ParseLevel(cfg.Log.Level)
 	if err != nil {
 		return nil, fmt.Errorf("parse log level %q: %w", cfg.Log.Level, err)
 	}
+	timeFormat, err := logTimeFormat(cfg.Log.TimeFormat)
+	if err != nil {
+		return nil, err
+	}
 
 	zerolog.SetGlobalLevel(level)
+	zerolog.TimeFieldFormat = timeFormat
 
 	var logger zerolog.Logger
 	if cfg.Log.Format == "console" {
-		logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout})
+		cw := zerolog.ConsoleWriter{Out: w, NoColor: !cfg.IsDevelopment()}
+		if !isUnixTimeFormat(timeFormat) {
+			cw.TimeFormat = timeFormat
+		}
+		logger = zerolog.New(cw)
 	} else {
-		logger = zerolog.New(os.Stdout)
+		logger = zerolog.New(w)
 	}
 
-	logger = logger.With().Timestamp().Logger()
+	lctx := logger.With().Timestamp().Str("version", buildinfo.Get(cfg.App.Name).Version)
+	if cfg.Log.IncludeCaller {
+		lctx = lctx.Caller()
+	}
+	logger = lctx.Logger()
+	zerolog.DefaultContextLogger = &logger
 
 	return &logger, nil
 }
+
+// LoggerFromContext returns the request-scoped logger stored in ctx by the
+// ContextLogger middleware, or the most recently built application logger
+// when ctx carries none.
+func LoggerFromContext(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// logTimeFormat maps LOG_TIME_FORMAT to a zerolog.TimeFieldFormat.
+func logTimeFormat(name string) (string, error) {
+	switch name {
+	case "", "rfc3339":
+		return time.RFC3339, nil
+	case "rfc3339nano":
+		return time.RFC3339Nano, nil
+	case "unix":
+		return zerolog.TimeFormatUnix, nil
+	case "unixms":
+		return zerolog.TimeFormatUnixMs, nil
+	case "unixmicro":
+		return zerolog.TimeFormatUnixMicro, nil
+	default:
+		return "", fmt.Errorf("unknown log time format %q", name)
+	}
+}
+
+func isUnixTimeFormat(f string) bool {
+	return f == zerolog.TimeFormatUnix || f == zerolog.TimeFormatUnixMs ||
+		f == zerolog.TimeFormatUnixMicro || f == zerolog.TimeFormatUnixNano
+}
@@ -3,15 +3,20 @@
 package telemetry_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zercle/zercle-go-template/internal/config"
+	"github.com/zercle/zercle-go-template/internal/shared/buildinfo"
 	"github.com/zercle/zercle-go-template/internal/shared/telemetry"
 )
 
@@ -36,6 +41,99 @@ func TestNewLogger_InvalidLevel(t *testing.T) {
 	require.Nil(t, logger)
 }
 
+// The logger tests below reconfigure zerolog's process-wide time format, so
+// they do not run in parallel.
+
+func TestNewLoggerTo_IncludeCaller(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{Log: config.LogConfig{Level: "info", Format: "json", IncludeCaller: true}}
+	logger, err := telemetry.NewLoggerTo(cfg, &buf)
+	require.NoError(t, err)
+
+	logger.Info().Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Contains(t, entry["caller"], "telemetry_test.go:")
+}
+
+func TestNewLoggerTo_IncludesVersion(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{Log: config.LogConfig{Level: "info", Format: "json"}}
+	logger, err := telemetry.NewLoggerTo(cfg, &buf)
+	require.NoError(t, err)
+
+	logger.Info().Msg("hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, buildinfo.Get("").Version, entry["version"])
+}
+
+func TestNewLoggerTo_NoCallerByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{Log: config.LogConfig{Level: "info", Format: "json"}}
+	logger, err := telemetry.NewLoggerTo(cfg, &buf)
+	require.NoError(t, err)
+
+	logger.Info().Msg("hello")
+
+	assert.NotContains(t, buf.String(), `"caller"`)
+}
+
+func TestNewLoggerTo_TimeFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		check  func(t *testing.T, v any)
+	}{
+		{"rfc3339", func(t *testing.T, v any) {
+			s, ok := v.(string)
+			require.True(t, ok)
+			_, err := time.Parse(time.RFC3339, s)
+			require.NoError(t, err)
+		}},
+		{"unixms", func(t *testing.T, v any) {
+			ms, ok := v.(float64)
+			require.True(t, ok)
+			assert.InDelta(t, float64(time.Now().UnixMilli()), ms, float64(time.Minute.Milliseconds()))
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			cfg := &config.Config{Log: config.LogConfig{Level: "info", Format: "json", TimeFormat: tc.format}}
+			logger, err := telemetry.NewLoggerTo(cfg, &buf)
+			require.NoError(t, err)
+			t.Cleanup(func() { zerolog.TimeFieldFormat = time.RFC3339 })
+
+			logger.Info().Msg("hello")
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			tc.check(t, entry["time"])
+		})
+	}
+}
+
+func TestNewLoggerTo_ConsoleIsReadable(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &config.Config{
+		App: config.AppConfig{Environment: "production"},
+		Log: config.LogConfig{Level: "info", Format: "console", IncludeCaller: true},
+	}
+	logger, err := telemetry.NewLoggerTo(cfg, &buf)
+	require.NoError(t, err)
+
+	logger.Info().Str("k", "v").Msg("hello")
+
+	out := buf.String()
+	assert.Contains(t, out, "INF")
+	assert.Contains(t, out, "hello")
+	assert.Contains(t, out, "k=v")
+	assert.Contains(t, out, "telemetry_test.go:")
+	assert.NotContains(t, out, "\x1b[", "console output is only colorized in development")
+}
+
 func TestNewTracer_None(t *testing.T) {
 	cfg := &config.Config{OTel: config.OTelConfig{Exporter: "none", ServiceName: "test"}}
 	provider, shutdown, err := telemetry.NewTracerProvider(context.Background(), cfg)
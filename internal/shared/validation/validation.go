@@ -0,0 +1,195 @@
+// Package validation builds the go-playground validator shared by request
+// binding and configuration, with the project's custom tags and English
+// messages for every tag registered once.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+)
+
+// Custom tags registered on Validator, in addition to validator's built-ins
+// (including uuid4, used as is).
+const (
+	// TagAlphaNumSpace accepts letters of any script (with their combining
+	// marks, as in Thai), digits, and spaces.
+	TagAlphaNumSpace = "alphanumspace"
+	// TagPasswordPolicy requires at least MinPasswordLength characters with
+	// an upper-case letter, a lower-case letter, and a digit.
+	TagPasswordPolicy = "password_policy"
+	// TagE164Phone accepts an E.164 phone number such as +66812345678.
+	TagE164Phone = "e164phone"
+)
+
+// MinPasswordLength is the shortest password TagPasswordPolicy accepts.
+const MinPasswordLength = 8
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// translator renders the messages registered on Validator.
+var translator = func() ut.Translator {
+	locale := en.New()
+	t, _ := ut.New(locale, locale).GetTranslator("en")
+	return t
+}()
+
+type customRule struct {
+	fn      validator.Func
+	message string
+}
+
+var customRules = map[string]customRule{
+	TagAlphaNumSpace:  {alphaNumSpace, "{0} must contain only letters, digits, and spaces"},
+	TagPasswordPolicy: {passwordPolicy, fmt.Sprintf("{0} must be at least %d characters with an upper-case letter, a lower-case letter, and a digit", MinPasswordLength)},
+	TagE164Phone:      {e164Phone, "{0} must be an E.164 phone number such as +66812345678"},
+}
+
+// Validator returns the process-wide validator with the custom tags and
+// English messages registered. Fields are named by their json tag, then
+// query, param, or mapstructure tag, falling back to the Go field name. It is
+// shared because translations are registered against one translator, and a
+// *validator.Validate is safe for concurrent use.
+func Validator() *validator.Validate {
+	return shared()
+}
+
+var shared = sync.OnceValue(func() *validator.Validate {
+	v := validator.New()
+	if err := register(v); err != nil {
+		// The tags and translations are static; failure is a programming
+		// error.
+		panic(err)
+	}
+	return v
+})
+
+// register adds the custom tags, their messages, the default English
+// messages, and the field naming to v.
+func register(v *validator.Validate) error {
+	v.RegisterTagNameFunc(fieldName)
+
+	if err := entranslations.RegisterDefaultTranslations(v, translator); err != nil {
+		return fmt.Errorf("register default translations: %w", err)
+	}
+	for tag, rule := range customRules {
+		if err := v.RegisterValidation(tag, rule.fn); err != nil {
+			return fmt.Errorf("register %s: %w", tag, err)
+		}
+		message := rule.message
+		err := v.RegisterTranslation(tag, translator,
+			func(t ut.Translator) error { return t.Add(tag, message, true) },
+			func(t ut.Translator, fe validator.FieldError) string {
+				s, _ := t.T(fe.Tag(), fe.Field())
+				return s
+			})
+		if err != nil {
+			return fmt.Errorf("register %s translation: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// fieldName names a struct field after its wire name.
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param", "mapstructure"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func alphaNumSpace(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) && r != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+func passwordPolicy(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	if len([]rune(s)) < MinPasswordLength {
+		return false
+	}
+	var upper, lower, digit bool
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+func e164Phone(fl validator.FieldLevel) bool {
+	return e164Pattern.MatchString(fl.Field().String())
+}
+
+// Message returns the English message for one failed field, including the
+// tag's parameter where it has one (e.g. "limit must be 100 or less").
+func Message(fe validator.FieldError) string {
+	return fe.Translate(translator)
+}
+
+// Details maps each failed field, by its path below the validated struct
+// (e.g. "name" or "address.city"), to its message. It returns nil when err is
+// not a validation error.
+func Details(err error) map[string]string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	details := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		details[fieldPath(fe)] = Message(fe)
+	}
+	return details
+}
+
+// Describe joins the messages of every failed field in err, or returns
+// err.Error() when err is not a validation error.
+func Describe(err error) string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err.Error()
+	}
+	msgs := make([]string, len(verrs))
+	for i, fe := range verrs {
+		msgs[i] = Message(fe)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// fieldPath drops the root struct name from the field's namespace. Var
+// validations have no namespace and map to their tag.
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	if ns != "" {
+		return ns
+	}
+	return fe.Tag()
+}
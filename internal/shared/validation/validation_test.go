@@ -0,0 +1,121 @@
+//go:build unit
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/internal/shared/validation"
+)
+
+func TestCustomTags(t *testing.T) {
+	t.Parallel()
+
+	v := validation.Validator()
+	tests := []struct {
+		tag   string
+		value string
+		ok    bool
+	}{
+		{"alphanumspace", "Room 101", true},
+		{"alphanumspace", "ห้อง 101", true},
+		{"alphanumspace", "Room-101", false},
+		{"alphanumspace", "drop;table", false},
+		{"alphanumspace", "", false},
+
+		{"password_policy", "Sup3rSecret", true},
+		{"password_policy", "Sh0rt", false},
+		{"password_policy", "alllowercase1", false},
+		{"password_policy", "ALLUPPERCASE1", false},
+		{"password_policy", "NoDigitsHere", false},
+
+		{"e164phone", "+66812345678", true},
+		{"e164phone", "+14155552671", true},
+		{"e164phone", "0812345678", false},
+		{"e164phone", "+0812345678", false},
+		{"e164phone", "+1234567890123456", false},
+		{"e164phone", "+66 81 234 5678", false},
+
+		{"uuid4", "3f1c8b9e-4a2d-4c6b-9f1e-2d3c4b5a6e7f", true},
+		{"uuid4", "3f1c8b9e-4a2d-1c6b-9f1e-2d3c4b5a6e7f", false},
+		{"uuid4", "not-a-uuid", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tag+"/"+tc.value, func(t *testing.T) {
+			t.Parallel()
+			err := v.Var(tc.value, tc.tag)
+			if tc.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	type signup struct {
+		Name     string `validate:"required,alphanumspace"`
+		Password string `validate:"password_policy"`
+		Phone    string `validate:"e164phone"`
+		ID       string `validate:"uuid4"`
+	}
+
+	err := validation.Validator().Struct(signup{Name: "a-b", Password: "weak", Phone: "123", ID: "x"})
+	require.Error(t, err)
+
+	msg := validation.Describe(err)
+	assert.Contains(t, msg, "Name must contain only letters, digits, and spaces")
+	assert.Contains(t, msg, "Password must be at least 8 characters")
+	assert.Contains(t, msg, "Phone must be an E.164 phone number")
+	assert.Contains(t, msg, "ID must be a valid version 4 UUID")
+}
+
+func TestDescribe_Required(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		Name string `validate:"required"`
+	}
+
+	err := validation.Validator().Struct(req{})
+	assert.Equal(t, "Name is a required field", validation.Describe(err))
+}
+
+func TestDetails(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type req struct {
+		Name    string  `json:"name" validate:"required,max=5"`
+		Limit   int     `query:"limit" validate:"lte=100"`
+		Status  string  `json:"status" validate:"oneof=active archived"`
+		Website string  `json:"website,omitempty" validate:"omitempty,url"`
+		Address address `json:"address"`
+	}
+
+	err := validation.Validator().Struct(req{Name: "too long", Limit: 500, Status: "gone", Website: "nope"})
+	require.Error(t, err)
+
+	assert.Equal(t, map[string]string{
+		"name":         "name must be a maximum of 5 characters in length",
+		"limit":        "limit must be 100 or less",
+		"status":       "status must be one of [active archived]",
+		"website":      "website must be a valid URL",
+		"address.city": "city is a required field",
+	}, validation.Details(err))
+}
+
+func TestDetails_NotValidationError(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, validation.Details(assert.AnError))
+	assert.Equal(t, assert.AnError.Error(), validation.Describe(assert.AnError))
+}
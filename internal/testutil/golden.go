@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// updateGoldenEnv names the environment variable that makes GoldenJSON
+// rewrite golden files instead of comparing against them.
+const updateGoldenEnv = "UPDATE_GOLDEN"
+
+// GoldenJSON marshals v and compares it with testdata/<name>.golden.json
+// relative to the calling test's package. A renamed, dropped, or re-typed
+// field, or a change in omitempty or null handling, therefore fails the
+// test. Run the tests with UPDATE_GOLDEN=1 to (re)write the golden files
+// after an intentional contract change, and review the diff.
+//
+// Only DTOs are pinned. Responses trimmed with a fields= selection are maps
+// built from a pinned DTO by pkg/fieldmask, so they are exempt and need no
+// golden file of their own.
+func GoldenJSON(t testing.TB, name string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if os.Getenv(updateGoldenEnv) != "" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+		return
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec // path is built from a test-supplied name under testdata
+	require.NoError(t, err, "missing golden file; run with %s=1 to create it", updateGoldenEnv)
+	require.Equal(t, string(want), string(got), "%s does not match the golden file", path)
+}
@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// JSONFields guards a DTO against JSON schema drift and returns its JSON
+// field names, sorted. It fails the test when an exported field has no json
+// tag (and would silently marshal under its Go name), when two fields map to
+// the same JSON name, or when v does not survive a marshal/strict-unmarshal
+// round trip unchanged. Pass a fully populated value so the round trip covers
+// every field. Embedded structs without a tag are flattened, as encoding/json
+// does.
+func JSONFields(t testing.TB, v any) []string {
+	t.Helper()
+
+	typ := reflect.TypeOf(v)
+	require.NotNil(t, typ, "JSONFields needs a non-nil value")
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	require.Equal(t, reflect.Struct, typ.Kind(), "JSONFields needs a struct, got %s", typ)
+
+	owners := make(map[string]string)
+	collectJSONFields(t, typ, "", owners)
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	decoded := reflect.New(typ)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	require.NoError(t, dec.Decode(decoded.Interface()), "decode %s", data)
+	require.Equal(t, reflect.Indirect(reflect.ValueOf(v)).Interface(), decoded.Elem().Interface(), "%s does not round-trip through JSON", typ)
+
+	names := make([]string, 0, len(owners))
+	for name := range owners {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// collectJSONFields records the JSON name of every exported field of typ in
+// owners, keyed by name with the Go field path as value.
+func collectJSONFields(t testing.TB, typ reflect.Type, prefix string, owners map[string]string) {
+	t.Helper()
+
+	for field := range typ.Fields() {
+		path := prefix + field.Name
+
+		tag, tagged := field.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" && !strings.Contains(tag, ",") {
+			continue
+		}
+
+		// Like encoding/json, promote the fields of untagged embedded structs,
+		// including unexported ones.
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJSONFields(t, embedded, path+".", owners)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if !tagged || name == "" {
+			t.Errorf("%s.%s has no json name", typ, path)
+			continue
+		}
+		if other, dup := owners[name]; dup {
+			t.Errorf("%s: fields %s and %s both map to json name %q", typ, other, path, name)
+			continue
+		}
+		owners[name] = path
+	}
+}
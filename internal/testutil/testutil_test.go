@@ -4,9 +4,11 @@ package testutil_test
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/labstack/echo/v5"
@@ -61,3 +63,77 @@ func TestNewRequest_NilBody(t *testing.T) {
 	body, _ := io.ReadAll(req.Body)
 	assert.Empty(t, body)
 }
+
+// recordingTB captures Errorf calls so JSONFields failures can be asserted.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) FailNow() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestJSONFields(t *testing.T) {
+	type embedded struct {
+		Page int `json:"page"`
+	}
+	type good struct {
+		embedded
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags,omitempty"`
+		Ignored string   `json:"-"`
+		hidden  string
+	}
+
+	got := testutil.JSONFields(t, good{embedded: embedded{Page: 2}, Name: "n", Tags: []string{"a"}})
+	require.Equal(t, []string{"name", "page", "tags"}, got)
+}
+
+func TestJSONFields_RejectsDrift(t *testing.T) {
+	type untagged struct {
+		Name      string `json:"name"`
+		StartTime string
+	}
+	// Built with reflection because go vet rejects repeated json tags in a
+	// struct literal type.
+	duplicate := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Name", Type: reflect.TypeFor[string](), Tag: `json:"name"`},
+		{Name: "Alias", Type: reflect.TypeFor[string](), Tag: `json:"name"`},
+	})).Elem().Interface()
+
+	tests := []struct {
+		name string
+		dto  any
+		want string
+	}{
+		{"untagged field", untagged{Name: "n"}, "StartTime has no json name"},
+		{"duplicate name", duplicate, `fields Name and Alias both map to json name "name"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &recordingTB{TB: t}
+			testutil.JSONFields(rec, tc.dto)
+			require.NotEmpty(t, rec.errors)
+			assert.Contains(t, rec.errors[0], tc.want)
+		})
+	}
+}
+
+func TestGoldenJSON(t *testing.T) {
+	type sample struct {
+		Count int     `json:"count"`
+		Note  *string `json:"note"`
+	}
+
+	testutil.GoldenJSON(t, "sample", sample{})
+
+	rec := &recordingTB{TB: t}
+	testutil.GoldenJSON(rec, "sample", sample{Count: 1})
+	require.NotEmpty(t, rec.errors, "a changed value must not match the golden file")
+}
@@ -0,0 +1,272 @@
+// Package breaker implements a circuit breaker for calls to external
+// dependencies. While a dependency keeps failing, calls fail fast with
+// ErrCircuitOpen instead of tying up goroutines on timeouts; after a sleep
+// window a few probe calls decide whether to close the circuit again.
+//
+// Nothing in the template calls an external service yet, so no Group is
+// wired into the container. A client that needs one builds it in its own DI
+// provider with NewGroup; an open circuit already maps to 503.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zercle/zercle-go-template/pkg/clock"
+)
+
+// ErrCircuitOpen is returned without calling the dependency while the circuit
+// is open, or half-open with all probe slots taken.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the circuit state.
+type State int
+
+// Circuit states.
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+// String returns the lower-case state name.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config tunes a Breaker. Zero fields take the defaults noted.
+type Config struct {
+	// Window is the length of the closed-state counting window (10s).
+	Window time.Duration
+	// MinRequests is the number of calls in a window before the failure
+	// rate is considered (10).
+	MinRequests uint64
+	// FailureRate in (0, 1] trips the circuit when reached (0.5).
+	FailureRate float64
+	// OpenTimeout is how long the circuit stays open before probing (30s).
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of successful probes needed to close
+	// the circuit, and the number allowed in flight at once (1).
+	HalfOpenProbes uint64
+}
+
+func (c Config) withDefaults() Config {
+	if c.Window <= 0 {
+		c.Window = 10 * time.Second
+	}
+	if c.MinRequests == 0 {
+		c.MinRequests = 10
+	}
+	if c.FailureRate <= 0 || c.FailureRate > 1 {
+		c.FailureRate = 0.5
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = 30 * time.Second
+	}
+	if c.HalfOpenProbes == 0 {
+		c.HalfOpenProbes = 1
+	}
+	return c
+}
+
+// Option configures a Breaker or Group.
+type Option func(*options)
+
+type options struct {
+	clock         clock.Clock
+	onStateChange func(name string, from, to State)
+	isFailure     func(error) bool
+}
+
+// WithClock reads time from clk instead of the system clock.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) { o.clock = clk }
+}
+
+// WithOnStateChange calls fn after every transition, outside the breaker's
+// lock. Use it to log or record metrics.
+func WithOnStateChange(fn func(name string, from, to State)) Option {
+	return func(o *options) { o.onStateChange = fn }
+}
+
+// WithIsFailure overrides which errors count as dependency failures. By
+// default every error does except context.Canceled, which means the caller
+// gave up rather than the dependency failing.
+func WithIsFailure(fn func(error) bool) Option {
+	return func(o *options) { o.isFailure = fn }
+}
+
+func defaultIsFailure(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
+
+// Stats is a snapshot of a Breaker.
+type Stats struct {
+	Name     string
+	State    State
+	Requests uint64
+	Failures uint64
+}
+
+// Breaker is a circuit breaker for one dependency. It is safe for concurrent
+// use.
+type Breaker struct {
+	name string
+	cfg  Config
+	opts options
+
+	mu          sync.Mutex
+	state       State
+	windowStart time.Time
+	requests    uint64
+	failures    uint64
+	openedAt    time.Time
+	inFlight    uint64
+	successes   uint64
+}
+
+// New returns a closed Breaker named name.
+func New(name string, cfg Config, opts ...Option) *Breaker {
+	o := options{clock: clock.New(), isFailure: defaultIsFailure}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Breaker{name: name, cfg: cfg.withDefaults(), opts: o, windowStart: o.clock.Now()}
+}
+
+// Do calls fn unless the circuit is open, in which case it returns
+// ErrCircuitOpen, and records the outcome. A ctx that is already done is
+// returned as is without counting.
+func (b *Breaker) Do(ctx context.Context, fn func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err //nolint:wrapcheck // the caller's own context error.
+	}
+	probe, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	err = fn(ctx)
+	b.record(probe, b.opts.isFailure(err))
+	return err
+}
+
+// State returns the current state, moving an expired open circuit to
+// half-open.
+func (b *Breaker) State() State {
+	return b.Stats().State
+}
+
+// Stats returns a snapshot of the breaker.
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	from, to := b.advanceLocked(b.opts.clock.Now())
+	s := Stats{Name: b.name, State: b.state, Requests: b.requests, Failures: b.failures}
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return s
+}
+
+// acquire admits a call, reporting whether it is a half-open probe.
+func (b *Breaker) acquire() (bool, error) {
+	b.mu.Lock()
+	from, to := b.advanceLocked(b.opts.clock.Now())
+
+	var (
+		probe bool
+		err   error
+	)
+	switch b.state {
+	case Open:
+		err = ErrCircuitOpen
+	case HalfOpen:
+		if b.inFlight >= b.cfg.HalfOpenProbes {
+			err = ErrCircuitOpen
+		} else {
+			b.inFlight++
+			probe = true
+		}
+	case Closed:
+	}
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return probe, err
+}
+
+// record counts an outcome and applies any resulting transition.
+func (b *Breaker) record(probe, failed bool) {
+	b.mu.Lock()
+	now := b.opts.clock.Now()
+	from, to := b.advanceLocked(now)
+
+	switch {
+	case probe && b.state == HalfOpen:
+		b.inFlight--
+		if failed {
+			from, to = b.setStateLocked(Open, now)
+			break
+		}
+		b.successes++
+		if b.successes >= b.cfg.HalfOpenProbes {
+			from, to = b.setStateLocked(Closed, now)
+		}
+	case b.state == Closed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.cfg.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.cfg.FailureRate {
+			from, to = b.setStateLocked(Open, now)
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// advanceLocked applies time-based transitions: rolling the closed window
+// and moving an expired open circuit to half-open.
+func (b *Breaker) advanceLocked(now time.Time) (State, State) {
+	switch b.state {
+	case Closed:
+		if now.Sub(b.windowStart) >= b.cfg.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	case Open:
+		if now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+			return b.setStateLocked(HalfOpen, now)
+		}
+	case HalfOpen:
+	}
+	return b.state, b.state
+}
+
+func (b *Breaker) setStateLocked(to State, now time.Time) (State, State) {
+	from := b.state
+	b.state = to
+	b.windowStart, b.requests, b.failures = now, 0, 0
+	b.inFlight, b.successes = 0, 0
+	if to == Open {
+		b.openedAt = now
+	}
+	return from, to
+}
+
+func (b *Breaker) notify(from, to State) {
+	if from != to && b.opts.onStateChange != nil {
+		b.opts.onStateChange(b.name, from, to)
+	}
+}
@@ -0,0 +1,178 @@
+//go:build unit
+
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/breaker"
+	"github.com/zercle/zercle-go-template/pkg/clock"
+)
+
+var errDown = errors.New("dependency down")
+
+type transition struct{ from, to breaker.State }
+
+func newTestBreaker(t *testing.T, cfg breaker.Config) (*breaker.Breaker, *clock.Fake, *[]transition) {
+	t.Helper()
+	clk := clock.NewFake(time.Unix(0, 0))
+	var transitions []transition
+	b := breaker.New("smtp", cfg,
+		breaker.WithClock(clk),
+		breaker.WithOnStateChange(func(name string, from, to breaker.State) {
+			assert.Equal(t, "smtp", name)
+			transitions = append(transitions, transition{from, to})
+		}),
+	)
+	return b, clk, &transitions
+}
+
+func succeed(context.Context) error { return nil }
+func fail(context.Context) error    { return errDown }
+
+func TestBreaker_FullCycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, clk, transitions := newTestBreaker(t, breaker.Config{
+		Window: time.Minute, MinRequests: 4, FailureRate: 0.5, OpenTimeout: 10 * time.Second, HalfOpenProbes: 2,
+	})
+
+	// Closed: below MinRequests nothing trips, even at 100% failures.
+	for range 3 {
+		require.ErrorIs(t, b.Do(ctx, fail), errDown)
+	}
+	require.Equal(t, breaker.Closed, b.State())
+
+	// The fourth call reaches MinRequests at a 100% failure rate.
+	require.NoError(t, b.Do(ctx, succeed))
+	require.Equal(t, breaker.Open, b.State())
+
+	// Open: calls fail fast without running fn.
+	called := false
+	err := b.Do(ctx, func(context.Context) error { called = true; return nil })
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen)
+	require.False(t, called)
+
+	// After the sleep window the circuit half-opens; a failed probe reopens it.
+	clk.Advance(10 * time.Second)
+	require.Equal(t, breaker.HalfOpen, b.State())
+	require.ErrorIs(t, b.Do(ctx, fail), errDown)
+	require.Equal(t, breaker.Open, b.State())
+
+	// Two successful probes close it.
+	clk.Advance(10 * time.Second)
+	require.NoError(t, b.Do(ctx, succeed))
+	require.Equal(t, breaker.HalfOpen, b.State())
+	require.NoError(t, b.Do(ctx, succeed))
+	require.Equal(t, breaker.Closed, b.State())
+
+	assert.Equal(t, []transition{
+		{breaker.Closed, breaker.Open},
+		{breaker.Open, breaker.HalfOpen},
+		{breaker.HalfOpen, breaker.Open},
+		{breaker.Open, breaker.HalfOpen},
+		{breaker.HalfOpen, breaker.Closed},
+	}, *transitions)
+}
+
+func TestBreaker_FailureRateBelowThresholdStaysClosed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, _, _ := newTestBreaker(t, breaker.Config{MinRequests: 4, FailureRate: 0.6})
+
+	for i := range 20 {
+		if i%3 == 0 {
+			_ = b.Do(ctx, fail)
+		} else {
+			_ = b.Do(ctx, succeed)
+		}
+	}
+
+	stats := b.Stats()
+	assert.Equal(t, breaker.Closed, stats.State)
+	assert.Equal(t, uint64(20), stats.Requests)
+	assert.Equal(t, uint64(7), stats.Failures)
+}
+
+func TestBreaker_WindowResetsCounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, clk, _ := newTestBreaker(t, breaker.Config{Window: time.Second, MinRequests: 2, FailureRate: 1})
+
+	require.Error(t, b.Do(ctx, fail))
+	clk.Advance(time.Second)
+	require.Error(t, b.Do(ctx, fail))
+
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.Equal(t, uint64(1), b.Stats().Failures)
+}
+
+func TestBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, clk, _ := newTestBreaker(t, breaker.Config{MinRequests: 1, OpenTimeout: time.Second})
+	require.Error(t, b.Do(ctx, fail))
+	clk.Advance(time.Second)
+
+	err := b.Do(ctx, func(ctx context.Context) error {
+		// A second call while the only probe is in flight fails fast.
+		return b.Do(ctx, succeed)
+	})
+
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen)
+	assert.Equal(t, breaker.Open, b.State(), "the probe's error counts as a failure")
+}
+
+func TestBreaker_CallerCancellationIsNotAFailure(t *testing.T) {
+	t.Parallel()
+
+	b, _, _ := newTestBreaker(t, breaker.Config{MinRequests: 1})
+
+	err := b.Do(context.Background(), func(context.Context) error {
+		return fmt.Errorf("send: %w", context.Canceled)
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, breaker.Closed, b.State())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, b.Do(ctx, fail), context.Canceled)
+	assert.Equal(t, uint64(1), b.Stats().Requests)
+}
+
+func TestGroup_PerNameBreakers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	g := breaker.NewGroup(breaker.Config{MinRequests: 1})
+
+	require.ErrorIs(t, g.Do(ctx, "smtp", fail), errDown)
+	require.ErrorIs(t, g.Do(ctx, "smtp", succeed), breaker.ErrCircuitOpen)
+	require.NoError(t, g.Do(ctx, "captcha", succeed))
+
+	stats := g.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "captcha", stats[0].Name)
+	assert.Equal(t, breaker.Closed, stats[0].State)
+	assert.Equal(t, "smtp", stats[1].Name)
+	assert.Equal(t, breaker.Open, stats[1].State)
+}
+
+func TestState_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "closed", breaker.Closed.String())
+	assert.Equal(t, "open", breaker.Open.String())
+	assert.Equal(t, "half-open", breaker.HalfOpen.String())
+}
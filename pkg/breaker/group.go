@@ -0,0 +1,58 @@
+package breaker
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Group lazily creates one Breaker per dependency name, all sharing the same
+// Config and options. It is safe for concurrent use.
+type Group struct {
+	cfg  Config
+	opts []Option
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewGroup returns an empty Group.
+func NewGroup(cfg Config, opts ...Option) *Group {
+	return &Group{cfg: cfg, opts: opts, breakers: make(map[string]*Breaker)}
+}
+
+// Get returns the breaker for name, creating it on first use.
+func (g *Group) Get(name string) *Breaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[name]
+	if !ok {
+		b = New(name, g.cfg, g.opts...)
+		g.breakers[name] = b
+	}
+	return b
+}
+
+// Do runs fn through the breaker for name.
+func (g *Group) Do(ctx context.Context, name string, fn func(context.Context) error) error {
+	return g.Get(name).Do(ctx, fn)
+}
+
+// Stats returns a snapshot of every breaker, sorted by name.
+func (g *Group) Stats() []Stats {
+	g.mu.Lock()
+	breakers := make([]*Breaker, 0, len(g.breakers))
+	for _, b := range g.breakers {
+		breakers = append(breakers, b)
+	}
+	g.mu.Unlock()
+
+	stats := make([]Stats, 0, len(breakers))
+	for _, b := range breakers {
+		stats = append(stats, b.Stats())
+	}
+	slices.SortFunc(stats, func(a, b Stats) int { return strings.Compare(a.Name, b.Name) })
+	return stats
+}
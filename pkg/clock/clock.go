@@ -0,0 +1,49 @@
+// Package clock abstracts the wall clock so time-dependent code can be
+// driven deterministically in tests. Production code takes a Clock (usually
+// from the DI container) instead of calling time.Now directly.
+package clock
+
+import "time"
+
+// Clock reports the current time and creates tickers.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the subset of *time.Ticker that callers use.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the system clock.
+type Real struct{}
+
+// New returns the system clock.
+func New() Clock {
+	return Real{}
+}
+
+// Now returns time.Now().
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns time.Since(t).
+func (Real) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// NewTicker wraps time.NewTicker.
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{t: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
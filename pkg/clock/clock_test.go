@@ -0,0 +1,86 @@
+//go:build unit
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/clock"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestReal_Now(t *testing.T) {
+	t.Parallel()
+
+	c := clock.New()
+	before := time.Now()
+	got := c.Now()
+
+	assert.False(t, got.Before(before))
+	assert.GreaterOrEqual(t, c.Since(before), time.Duration(0))
+}
+
+func TestFake_Advance(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewFake(epoch)
+	require.Equal(t, epoch, c.Now())
+
+	c.Advance(90 * time.Second)
+
+	assert.Equal(t, epoch.Add(90*time.Second), c.Now())
+	assert.Equal(t, 90*time.Second, c.Since(epoch))
+
+	c.Set(epoch)
+	assert.Equal(t, epoch, c.Now())
+}
+
+func TestFake_Ticker(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewFake(epoch)
+	tk := c.NewTicker(time.Minute)
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-tk.C():
+		t.Fatal("ticked before the period elapsed")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case got := <-tk.C():
+		assert.Equal(t, epoch.Add(time.Minute), got)
+	default:
+		t.Fatal("did not tick after the period elapsed")
+	}
+
+	// Missed ticks are dropped rather than queued.
+	c.Advance(5 * time.Minute)
+	<-tk.C()
+	select {
+	case <-tk.C():
+		t.Fatal("queued more than one tick")
+	default:
+	}
+
+	tk.Stop()
+	c.Advance(time.Minute)
+	select {
+	case <-tk.C():
+		t.Fatal("ticked after Stop")
+	default:
+	}
+}
+
+func TestFake_NewTickerPanicsOnNonPositive(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { clock.NewFake(epoch).NewTicker(0) })
+}
@@ -0,0 +1,106 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock that only moves when Advance or Set is called. Tickers
+// created from it fire synchronously inside Advance, once per elapsed
+// period, dropping ticks a slow reader has not consumed (as time.Ticker
+// does). It is safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// NewFake returns a Fake clock reading now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// NewTicker returns a ticker driven by Advance. It panics on a non-positive
+// d, like time.NewTicker.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTicker{c: make(chan time.Time, 1), period: d, next: f.now.Add(d)}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires any tickers that came due.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(f.now.Add(d))
+}
+
+// Set moves the clock to t. Moving backwards does not fire tickers.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(t)
+}
+
+func (f *Fake) setLocked(t time.Time) {
+	f.now = t
+	live := f.tickers[:0]
+	for _, tk := range f.tickers {
+		if tk.fire(t) {
+			live = append(live, tk)
+		}
+	}
+	f.tickers = live
+}
+
+type fakeTicker struct {
+	mu      sync.Mutex
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+}
+
+// fire delivers every tick due at or before now and reports whether the
+// ticker is still live.
+func (t *fakeTicker) fire(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	for !t.next.After(now) {
+		select {
+		case t.c <- t.next:
+		default:
+		}
+		t.next = t.next.Add(t.period)
+	}
+	return true
+}
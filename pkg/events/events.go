@@ -0,0 +1,302 @@
+// Package events is an in-process publish/subscribe bus, so features that
+// react to each other's changes (notifications, audit, cache invalidation,
+// SSE) share one mechanism instead of wiring their own channels.
+//
+// An event is any type with a Topic method. Subscribers get their own
+// buffered queue and goroutine, so a slow subscriber never blocks the
+// publisher or other subscribers; when its queue is full the oldest queued
+// event is dropped and counted:
+//
+//	type ItemRenamed struct{ ID uuid.UUID; Name string }
+//
+//	func (ItemRenamed) Topic() string { return "example.item_renamed" }
+//
+//	unsubscribe := events.SubscribeTo(bus, func(ctx context.Context, e ItemRenamed) {
+//		...
+//	})
+//	defer unsubscribe()
+//
+//	_ = bus.Publish(ctx, ItemRenamed{ID: id, Name: name})
+//
+// Delivery is at most once and in publish order per subscriber. Nothing is
+// persisted; use it for reactions that may be lost on a crash.
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned by Publish after Close.
+var ErrClosed = errors.New("event bus is closed")
+
+// DefaultQueueSize is the per-subscriber queue length used when WithQueueSize
+// is not given.
+const DefaultQueueSize = 64
+
+// Event is a message published on the bus. Topic names the subscribers it is
+// delivered to and should be constant per type.
+type Event interface {
+	Topic() string
+}
+
+// Handler processes one event. ctx carries the publisher's values, such as
+// the trace and request logger, but not its cancellation.
+type Handler func(ctx context.Context, e Event)
+
+// Option configures a Bus.
+type Option func(*options)
+
+type options struct {
+	queueSize int
+	onPanic   func(topic string, recovered any)
+}
+
+// WithQueueSize sets the per-subscriber queue length. Values below 1 are
+// ignored.
+func WithQueueSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.queueSize = n
+		}
+	}
+}
+
+// WithOnPanic calls fn with the value recovered from a handler that panicked.
+// The subscriber keeps receiving events either way. Use it to log or record
+// metrics.
+func WithOnPanic(fn func(topic string, recovered any)) Option {
+	return func(o *options) { o.onPanic = fn }
+}
+
+// Bus delivers published events to the subscribers of their topic. It is safe
+// for concurrent use. The zero value is not usable; call New.
+type Bus struct {
+	opts    options
+	dropped atomic.Uint64
+
+	mu      sync.RWMutex
+	subs    map[string][]*subscriber
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// New returns an open Bus.
+func New(opts ...Option) *Bus {
+	o := options{queueSize: DefaultQueueSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Bus{opts: o, subs: make(map[string][]*subscriber)}
+}
+
+// Subscribe registers h for events on topic and returns a function that
+// removes it; events still queued for h are discarded. Subscribing to a
+// closed bus returns a no-op unsubscribe and h is never called.
+func (b *Bus) Subscribe(topic string, h Handler) (unsubscribe func()) {
+	s := &subscriber{
+		topic:   topic,
+		handler: h,
+		bus:     b,
+		queue:   make([]queued, 0, b.opts.queueSize),
+		wake:    make(chan struct{}, 1),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return func() {}
+	}
+	b.subs[topic] = append(b.subs[topic], s)
+	b.workers.Add(1)
+	b.mu.Unlock()
+
+	go s.run()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.remove(s)
+			s.stop(true)
+		})
+	}
+}
+
+// SubscribeTo subscribes fn to the topic of E, the event type it accepts.
+func SubscribeTo[E Event](b *Bus, fn func(ctx context.Context, e E)) (unsubscribe func()) {
+	var zero E
+	return b.Subscribe(zero.Topic(), func(ctx context.Context, e Event) {
+		if typed, ok := e.(E); ok {
+			fn(ctx, typed)
+		}
+	})
+}
+
+// Publish queues e for every subscriber of its topic and returns without
+// waiting for them. A full queue drops its oldest event to make room.
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	item := queued{ctx: context.WithoutCancel(ctx), event: e}
+	for _, s := range b.subs[e.Topic()] {
+		s.enqueue(item)
+	}
+	return nil
+}
+
+// Dropped returns the number of events dropped because a subscriber's queue
+// was full.
+func (b *Bus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Close stops accepting events and waits for the subscribers to handle what
+// is already queued. If ctx ends first, the remaining queued events are
+// discarded and ctx's error is returned; a handler that is still running is
+// not interrupted.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	var subs []*subscriber
+	for _, list := range b.subs {
+		subs = append(subs, list...)
+	}
+	b.subs = nil
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.stop(false)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, s := range subs {
+			s.stop(true)
+		}
+		return ctx.Err() //nolint:wrapcheck // the caller's own context error.
+	}
+}
+
+func (b *Bus) remove(s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	list := b.subs[s.topic]
+	for i, other := range list {
+		if other == s {
+			b.subs[s.topic] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+}
+
+type queued struct {
+	ctx   context.Context
+	event Event
+}
+
+// subscriber owns one handler's queue and the goroutine that drains it.
+type subscriber struct {
+	topic   string
+	handler Handler
+	bus     *Bus
+
+	mu      sync.Mutex
+	queue   []queued
+	closing bool // deliver what is queued, then exit
+	discard bool // exit without delivering what is queued
+	wake    chan struct{}
+}
+
+func (s *subscriber) enqueue(item queued) {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return
+	}
+	if len(s.queue) == cap(s.queue) {
+		s.queue = append(s.queue[:0], s.queue[1:]...)
+		s.bus.dropped.Add(1)
+	}
+	s.queue = append(s.queue, item)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// stop ends the subscriber after it drains its queue or, with discard,
+// immediately after the handler that is running.
+func (s *subscriber) stop(discard bool) {
+	s.mu.Lock()
+	s.closing = true
+	if discard {
+		s.discard = true
+		s.queue = s.queue[:0]
+	}
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *subscriber) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) run() {
+	defer s.bus.workers.Done()
+	for {
+		item, ok, exit := s.next()
+		if exit {
+			return
+		}
+		if !ok {
+			<-s.wake
+			continue
+		}
+		s.deliver(item)
+	}
+}
+
+// next pops the oldest queued event, reporting whether there was one and
+// whether the subscriber should exit.
+func (s *subscriber) next() (queued, bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.discard {
+		return queued{}, false, true
+	}
+	if len(s.queue) == 0 {
+		return queued{}, false, s.closing
+	}
+	item := s.queue[0]
+	s.queue = append(s.queue[:0], s.queue[1:]...)
+	return item, true, false
+}
+
+func (s *subscriber) deliver(item queued) {
+	defer func() {
+		if r := recover(); r != nil && s.bus.opts.onPanic != nil {
+			s.bus.opts.onPanic(s.topic, r)
+		}
+	}()
+	s.handler(item.ctx, item.event)
+}
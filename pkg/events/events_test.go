@@ -0,0 +1,214 @@
+//go:build unit
+
+package events_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/zercle/zercle-go-template/pkg/events"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+type itemRenamed struct{ n int }
+
+func (itemRenamed) Topic() string { return "item_renamed" }
+
+type itemDeleted struct{}
+
+func (itemDeleted) Topic() string { return "item_deleted" }
+
+// recorder collects the events a subscriber receives.
+type recorder struct {
+	mu  sync.Mutex
+	got []int
+}
+
+func (r *recorder) add(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, n)
+}
+
+func (r *recorder) snapshot() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.got...)
+}
+
+func closeBus(t *testing.T, bus *events.Bus) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Close(ctx))
+}
+
+func TestBus_DeliversInOrderPerSubscriber(t *testing.T) {
+	t.Parallel()
+
+	bus := events.New(events.WithQueueSize(1000))
+	var a, b recorder
+	events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) { a.add(e.n) })
+	events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) { b.add(e.n) })
+	events.SubscribeTo(bus, func(context.Context, itemDeleted) { t.Error("delivered to the wrong topic") })
+
+	want := make([]int, 500)
+	for i := range want {
+		want[i] = i
+		require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: i}))
+	}
+	closeBus(t, bus)
+
+	assert.Equal(t, want, a.snapshot())
+	assert.Equal(t, want, b.snapshot())
+	assert.Zero(t, bus.Dropped())
+}
+
+func TestBus_DropsOldestForSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	bus := events.New(events.WithQueueSize(2))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var slow, fast recorder
+	events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) {
+		if e.n == 0 {
+			close(started)
+			<-release
+		}
+		slow.add(e.n)
+	})
+
+	require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: 0}))
+	<-started
+
+	// Subscribed after the slow handler is busy, so it sees only 1..4.
+	events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) { fast.add(e.n) })
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: i}))
+	}
+	close(release)
+	closeBus(t, bus)
+
+	// The slow subscriber's queue of two kept the newest events; the fast
+	// one may have dropped some too, but never blocked the publisher.
+	assert.Equal(t, []int{0, 3, 4}, slow.snapshot())
+	assert.GreaterOrEqual(t, bus.Dropped(), uint64(2))
+	assert.Subset(t, []int{1, 2, 3, 4}, fast.snapshot())
+}
+
+func TestBus_IsolatesPanics(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		panics []any
+	)
+	bus := events.New(events.WithOnPanic(func(topic string, recovered any) {
+		assert.Equal(t, "item_renamed", topic)
+		mu.Lock()
+		panics = append(panics, recovered)
+		mu.Unlock()
+	}))
+
+	var got recorder
+	events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) {
+		if e.n == 1 {
+			panic("boom")
+		}
+		got.add(e.n)
+	})
+	for i := range 3 {
+		require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: i}))
+	}
+	closeBus(t, bus)
+
+	assert.Equal(t, []int{0, 2}, got.snapshot())
+	assert.Equal(t, []any{"boom"}, panics)
+}
+
+func TestBus_HandlerContextOutlivesPublisher(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	bus := events.New()
+	got := make(chan context.Context, 1)
+	bus.Subscribe("item_renamed", func(ctx context.Context, _ events.Event) { got <- ctx })
+
+	ctx, cancel := context.WithCancel(context.WithValue(t.Context(), key{}, "v"))
+	require.NoError(t, bus.Publish(ctx, itemRenamed{}))
+	cancel()
+	closeBus(t, bus)
+
+	handlerCtx := <-got
+	assert.Equal(t, "v", handlerCtx.Value(key{}))
+	assert.NoError(t, handlerCtx.Err())
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	bus := events.New()
+	var got recorder
+	unsubscribe := events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) { got.add(e.n) })
+	unsubscribe()
+	unsubscribe()
+
+	require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: 1}))
+	closeBus(t, bus)
+	assert.Empty(t, got.snapshot())
+}
+
+func TestBus_Close(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects publishes and subscriptions afterwards", func(t *testing.T) {
+		t.Parallel()
+
+		bus := events.New()
+		closeBus(t, bus)
+		closeBus(t, bus)
+
+		require.ErrorIs(t, bus.Publish(t.Context(), itemRenamed{}), events.ErrClosed)
+		bus.Subscribe("item_renamed", func(context.Context, events.Event) { t.Error("handler called after close") })()
+	})
+
+	t.Run("gives up on the queue at the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		bus := events.New()
+		release := make(chan struct{})
+		started := make(chan struct{})
+		var got recorder
+		events.SubscribeTo(bus, func(_ context.Context, e itemRenamed) {
+			if e.n == 0 {
+				close(started)
+				<-release
+			}
+			got.add(e.n)
+		})
+		for i := range 3 {
+			require.NoError(t, bus.Publish(t.Context(), itemRenamed{n: i}))
+		}
+		<-started
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, bus.Close(ctx), context.DeadlineExceeded)
+
+		// The running handler finishes; the queued events are discarded.
+		close(release)
+		require.Eventually(t, func() bool { return len(got.snapshot()) == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, []int{0}, got.snapshot())
+	})
+}
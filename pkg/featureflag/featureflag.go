@@ -0,0 +1,102 @@
+// Package featureflag evaluates feature flags with sticky percentage
+// rollouts. Flags come from a Provider (static, cached, or any backing store);
+// the evaluated set for a request is carried in its context so handlers and
+// services can call IsEnabled without extra plumbing.
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"time"
+)
+
+// ErrInvalidFlag is returned (wrapped) when a flag fails validation.
+var ErrInvalidFlag = errors.New("invalid feature flag")
+
+// Flag is a single feature flag definition.
+type Flag struct {
+	Key     string
+	Enabled bool
+	// RolloutPercent is the share of subjects, 0-100, for which an enabled
+	// flag evaluates to true.
+	RolloutPercent int
+	UpdatedAt      time.Time
+}
+
+// Validate reports whether f is well-formed.
+func (f Flag) Validate() error {
+	if f.Key == "" {
+		return errors.Join(ErrInvalidFlag, errors.New("key is required"))
+	}
+	if f.RolloutPercent < 0 || f.RolloutPercent > 100 {
+		return errors.Join(ErrInvalidFlag, errors.New("rollout percent must be between 0 and 100"))
+	}
+	return nil
+}
+
+// Provider returns the current flag definitions keyed by flag key.
+// Implementations must be safe for concurrent use and must not let callers
+// mutate their internal state through the returned map.
+type Provider interface {
+	Flags(ctx context.Context) (map[string]Flag, error)
+}
+
+// EnabledFor reports whether f is on for subject. A disabled flag is always
+// off and a 100% rollout is always on. Partial rollouts hash the flag key and
+// subject into a stable bucket, so the same subject always gets the same
+// verdict for a given flag and percentage; an empty subject is never inside a
+// partial rollout.
+func (f Flag) EnabledFor(subject string) bool {
+	switch {
+	case !f.Enabled || f.RolloutPercent <= 0:
+		return false
+	case f.RolloutPercent >= 100:
+		return true
+	case subject == "":
+		return false
+	default:
+		return bucket(f.Key, subject) < uint32(f.RolloutPercent) //nolint:gosec // RolloutPercent is within (0, 100) here.
+	}
+}
+
+// bucket maps key and subject to a stable value in [0, 100).
+func bucket(key, subject string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(subject))
+	return h.Sum32() % 100
+}
+
+// Set is the evaluated verdict of every known flag for one subject.
+type Set map[string]bool
+
+// Evaluate computes the Set of flags for subject.
+func Evaluate(flags map[string]Flag, subject string) Set {
+	set := make(Set, len(flags))
+	for key, f := range flags {
+		set[key] = f.EnabledFor(subject)
+	}
+	return set
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying set.
+func NewContext(ctx context.Context, set Set) context.Context {
+	return context.WithValue(ctx, contextKey{}, set)
+}
+
+// FromContext returns the Set stored in ctx, if any.
+func FromContext(ctx context.Context) (Set, bool) {
+	set, ok := ctx.Value(contextKey{}).(Set)
+	return set, ok
+}
+
+// IsEnabled reports whether key is enabled for the request carried by ctx.
+// Unknown flags, and contexts without an evaluated Set, are off.
+func IsEnabled(ctx context.Context, key string) bool {
+	set, _ := FromContext(ctx)
+	return set[key]
+}
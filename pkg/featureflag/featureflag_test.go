@@ -0,0 +1,99 @@
+//go:build unit
+
+package featureflag_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+func TestFlag_EnabledFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		flag    featureflag.Flag
+		subject string
+		want    bool
+	}{
+		{"disabled", featureflag.Flag{Key: "f", RolloutPercent: 100}, "u1", false},
+		{"zero percent", featureflag.Flag{Key: "f", Enabled: true}, "u1", false},
+		{"full rollout", featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, "u1", true},
+		{"full rollout anonymous", featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, "", true},
+		{"partial rollout anonymous", featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 99}, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, tc.flag.EnabledFor(tc.subject))
+		})
+	}
+}
+
+func TestFlag_EnabledFor_Sticky(t *testing.T) {
+	t.Parallel()
+
+	flag := featureflag.Flag{Key: "new-availability", Enabled: true, RolloutPercent: 30}
+
+	on := 0
+	for i := range 1000 {
+		subject := fmt.Sprintf("user-%d", i)
+		first := flag.EnabledFor(subject)
+		for range 5 {
+			require.Equal(t, first, flag.EnabledFor(subject), "verdict changed for %s", subject)
+		}
+		if first {
+			on++
+		}
+	}
+
+	// 30% of 1000 subjects, with generous slack for hash distribution.
+	require.InDelta(t, 300, on, 60)
+}
+
+func TestFlag_EnabledFor_RolloutOnlyGrows(t *testing.T) {
+	t.Parallel()
+
+	low := featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 10}
+	high := featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 50}
+
+	for i := range 1000 {
+		subject := fmt.Sprintf("user-%d", i)
+		if low.EnabledFor(subject) {
+			require.True(t, high.EnabledFor(subject), "%s dropped out when the rollout grew", subject)
+		}
+	}
+}
+
+func TestFlag_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, featureflag.Flag{Key: "f", RolloutPercent: 0}.Validate())
+	require.NoError(t, featureflag.Flag{Key: "f", RolloutPercent: 100}.Validate())
+	require.ErrorIs(t, featureflag.Flag{RolloutPercent: 50}.Validate(), featureflag.ErrInvalidFlag)
+	require.ErrorIs(t, featureflag.Flag{Key: "f", RolloutPercent: 101}.Validate(), featureflag.ErrInvalidFlag)
+	require.ErrorIs(t, featureflag.Flag{Key: "f", RolloutPercent: -1}.Validate(), featureflag.ErrInvalidFlag)
+}
+
+func TestIsEnabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	require.False(t, featureflag.IsEnabled(ctx, "f"))
+
+	set := featureflag.Evaluate(map[string]featureflag.Flag{
+		"on":  {Key: "on", Enabled: true, RolloutPercent: 100},
+		"off": {Key: "off", RolloutPercent: 100},
+	}, "u1")
+	ctx = featureflag.NewContext(ctx, set)
+
+	require.True(t, featureflag.IsEnabled(ctx, "on"))
+	require.False(t, featureflag.IsEnabled(ctx, "off"))
+	require.False(t, featureflag.IsEnabled(ctx, "unknown"))
+}
@@ -0,0 +1,155 @@
+// Provider implementations: static, cached, and fallback.
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Static is a fixed, in-memory Provider.
+type Static struct {
+	flags map[string]Flag
+}
+
+// NewStatic returns a Static provider serving flags.
+func NewStatic(flags ...Flag) *Static {
+	m := make(map[string]Flag, len(flags))
+	for _, f := range flags {
+		m[f.Key] = f
+	}
+	return &Static{flags: m}
+}
+
+// Flags implements Provider.
+func (s *Static) Flags(context.Context) (map[string]Flag, error) {
+	return maps.Clone(s.flags), nil
+}
+
+// Cached wraps a Provider and serves its last result for ttl. When a refresh
+// fails and a previous snapshot exists, the stale snapshot keeps being served
+// for another ttl instead of failing every request during an outage. When
+// there is no snapshot yet, the failure itself is returned for ttl before
+// the source is tried again.
+//
+// The source is never called under the lock: concurrent refreshes collapse
+// into one call, so a slow source costs one round trip rather than one per
+// caller in turn.
+type Cached struct {
+	source Provider
+	ttl    time.Duration
+	now    func() time.Time
+	group  singleflight.Group
+
+	mu         sync.Mutex
+	snapshot   map[string]Flag
+	expiresAt  time.Time
+	lastErr    error
+	retryAt    time.Time
+	generation uint64
+}
+
+// NewCached returns a Cached provider over source. A nil now uses time.Now.
+func NewCached(source Provider, ttl time.Duration, now func() time.Time) *Cached {
+	if now == nil {
+		now = time.Now
+	}
+	return &Cached{source: source, ttl: ttl, now: now}
+}
+
+// Flags implements Provider.
+func (c *Cached) Flags(ctx context.Context) (map[string]Flag, error) {
+	c.mu.Lock()
+	now := c.now()
+	switch {
+	case c.snapshot != nil && now.Before(c.expiresAt):
+		flags := maps.Clone(c.snapshot)
+		c.mu.Unlock()
+		return flags, nil
+	case c.snapshot == nil && c.lastErr != nil && now.Before(c.retryAt):
+		err := c.lastErr
+		c.mu.Unlock()
+		return nil, fmt.Errorf("refresh feature flags: %w", err)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	// The refresh is shared, so it must not fail because the caller that
+	// happened to start it went away.
+	res, err, _ := c.group.Do(strconv.FormatUint(generation, 10), func() (any, error) {
+		flags, err := c.source.Flags(context.WithoutCancel(ctx))
+		return c.store(generation, flags, err)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refresh feature flags: %w", err)
+	}
+	flags, _ := res.(map[string]Flag)
+	return maps.Clone(flags), nil
+}
+
+// store records the outcome of a refresh started at generation and returns
+// the flags callers should see: the new snapshot, the stale one when the
+// refresh failed, or the error when there is nothing to fall back on.
+func (c *Cached) store(generation uint64, flags map[string]Flag, err error) (map[string]Flag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if generation != c.generation {
+		// Invalidate ran meanwhile; hand the result back without caching it.
+		return flags, err
+	}
+	if err != nil {
+		if c.snapshot == nil {
+			c.lastErr = err
+			c.retryAt = now.Add(c.ttl)
+			return nil, err
+		}
+		c.expiresAt = now.Add(c.ttl)
+		return c.snapshot, nil
+	}
+	c.snapshot = flags
+	c.expiresAt = now.Add(c.ttl)
+	c.lastErr = nil
+	return flags, nil
+}
+
+// Invalidate drops the cached snapshot and any remembered failure so the
+// next Flags call refreshes. Refreshes already in flight are not cached and
+// are not shared with later calls.
+func (c *Cached) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot = nil
+	c.lastErr = nil
+	c.generation++
+}
+
+// Fallback returns a Provider that serves primary and falls back to
+// secondary when primary fails.
+func Fallback(primary, secondary Provider) Provider {
+	return fallback{primary: primary, secondary: secondary}
+}
+
+type fallback struct {
+	primary   Provider
+	secondary Provider
+}
+
+// Flags implements Provider.
+func (f fallback) Flags(ctx context.Context) (map[string]Flag, error) {
+	flags, err := f.primary.Flags(ctx)
+	if err == nil {
+		return flags, nil
+	}
+	flags, err = f.secondary.Flags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fallback feature flags: %w", err)
+	}
+	return flags, nil
+}
@@ -0,0 +1,218 @@
+//go:build unit
+
+package featureflag_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/featureflag"
+)
+
+// mutableProvider is a test Provider whose flags and error can be changed.
+type mutableProvider struct {
+	mu    sync.Mutex
+	flags map[string]featureflag.Flag
+	err   error
+	calls int
+}
+
+func (p *mutableProvider) Flags(context.Context) (map[string]featureflag.Flag, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return maps.Clone(p.flags), nil
+}
+
+func (p *mutableProvider) set(f featureflag.Flag, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.flags = map[string]featureflag.Flag{f.Key: f}
+	p.err = err
+}
+
+func TestCached_ExpiryPicksUpToggledFlag(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &mutableProvider{}
+	source.set(featureflag.Flag{Key: "f", RolloutPercent: 100}, nil)
+	cached := featureflag.NewCached(source, 30*time.Second, func() time.Time { return now })
+
+	flags, err := cached.Flags(ctx)
+	require.NoError(t, err)
+	require.False(t, flags["f"].Enabled)
+
+	source.set(featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, nil)
+
+	now = now.Add(29 * time.Second)
+	flags, err = cached.Flags(ctx)
+	require.NoError(t, err)
+	require.False(t, flags["f"].Enabled, "served from cache before the TTL elapses")
+	require.Equal(t, 1, source.calls)
+
+	now = now.Add(time.Second)
+	flags, err = cached.Flags(ctx)
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled, "toggle visible once the TTL elapses")
+	require.Equal(t, 2, source.calls)
+}
+
+func TestCached_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := &mutableProvider{}
+	source.set(featureflag.Flag{Key: "f", RolloutPercent: 100}, nil)
+	cached := featureflag.NewCached(source, time.Hour, nil)
+
+	_, err := cached.Flags(ctx)
+	require.NoError(t, err)
+
+	source.set(featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, nil)
+	cached.Invalidate()
+
+	flags, err := cached.Flags(ctx)
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled)
+}
+
+func TestCached_ServesStaleOnError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &mutableProvider{}
+	source.set(featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, nil)
+	cached := featureflag.NewCached(source, time.Second, func() time.Time { return now })
+
+	_, err := cached.Flags(ctx)
+	require.NoError(t, err)
+
+	source.set(featureflag.Flag{}, errors.New("db down"))
+	now = now.Add(2 * time.Second)
+
+	flags, err := cached.Flags(ctx)
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled)
+}
+
+func TestCached_ErrorWithoutSnapshot(t *testing.T) {
+	t.Parallel()
+
+	source := &mutableProvider{err: errors.New("db down")}
+	cached := featureflag.NewCached(source, time.Second, nil)
+
+	flags, err := cached.Flags(context.Background())
+	require.Error(t, err)
+	require.Nil(t, flags)
+}
+
+func TestCached_FailedFirstLoadRetriesAfterTTL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &mutableProvider{err: errors.New("db down")}
+	cached := featureflag.NewCached(source, 10*time.Second, func() time.Time { return now })
+
+	for range 3 {
+		_, err := cached.Flags(ctx)
+		require.Error(t, err)
+	}
+	require.Equal(t, 1, source.calls, "failure is remembered until the retry time")
+
+	source.set(featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, nil)
+	now = now.Add(10 * time.Second)
+	flags, err := cached.Flags(ctx)
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled)
+	require.Equal(t, 2, source.calls)
+}
+
+func TestCached_InvalidateRetriesFailedLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := &mutableProvider{err: errors.New("db down")}
+	cached := featureflag.NewCached(source, time.Hour, nil)
+
+	_, err := cached.Flags(ctx)
+	require.Error(t, err)
+
+	source.set(featureflag.Flag{Key: "f", Enabled: true, RolloutPercent: 100}, nil)
+	cached.Invalidate()
+	flags, err := cached.Flags(ctx)
+	require.NoError(t, err)
+	require.True(t, flags["f"].Enabled)
+}
+
+// blockingProvider counts calls and holds each one until release is closed.
+type blockingProvider struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (p *blockingProvider) Flags(context.Context) (map[string]featureflag.Flag, error) {
+	p.calls.Add(1)
+	<-p.release
+	return map[string]featureflag.Flag{"f": {Key: "f", Enabled: true, RolloutPercent: 100}}, nil
+}
+
+func TestCached_CollapsesConcurrentRefreshes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := &blockingProvider{release: make(chan struct{})}
+	cached := featureflag.NewCached(source, time.Hour, nil)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make([]bool, callers)
+	for i := range callers {
+		wg.Go(func() {
+			flags, err := cached.Flags(ctx)
+			results[i] = err == nil && flags["f"].Enabled
+		})
+	}
+	require.Eventually(t, func() bool { return source.calls.Load() == 1 }, time.Second, time.Millisecond)
+	// Give the remaining callers a moment to join the in-flight refresh;
+	// stragglers are served from the snapshot either way.
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, source.calls.Load())
+	for _, ok := range results {
+		require.True(t, ok)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	primary := &mutableProvider{err: errors.New("db down")}
+	static := featureflag.NewStatic(featureflag.Flag{Key: "static", Enabled: true, RolloutPercent: 100})
+
+	flags, err := featureflag.Fallback(primary, static).Flags(ctx)
+	require.NoError(t, err)
+	require.Contains(t, flags, "static")
+
+	primary.set(featureflag.Flag{Key: "db", Enabled: true, RolloutPercent: 100}, nil)
+	flags, err = featureflag.Fallback(primary, static).Flags(ctx)
+	require.NoError(t, err)
+	require.Contains(t, flags, "db")
+	require.NotContains(t, flags, "static")
+}
@@ -0,0 +1,170 @@
+// Package fieldmask parses client-supplied field selections such as
+// "id,name,owner.email" against an allowlist and trims JSON objects down to
+// the selected fields.
+//
+// The allowlist is usually derived from a response type with Allowed, so a
+// client can only select fields the type already serializes. Selections may
+// reach one level into embedded objects ("owner.email"); deeper paths are
+// rejected.
+package fieldmask
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidFields is returned (wrapped) for malformed selections and
+// fields outside the allowlist.
+var ErrInvalidFields = errors.New("invalid fields")
+
+// Mask is a parsed selection. It maps each selected top-level field to the
+// nested fields selected within it; a nil entry selects the whole value. A
+// nil Mask selects everything.
+type Mask map[string][]string
+
+// Allowed lists the JSON field names of struct type T: each exported field's
+// json tag name, plus "parent.child" for the fields of a struct-typed (or
+// pointer-to-struct or slice-of-struct) field. Fields tagged "-" are
+// skipped.
+func Allowed[T any]() []string {
+	var fields []string
+	for name, typ := range jsonFields(reflect.TypeFor[T]()) {
+		fields = append(fields, name)
+		for child := range jsonFields(typ) {
+			fields = append(fields, name+"."+child)
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// jsonFields yields the JSON name and type of every serialized field of t,
+// dereferencing pointers and slices. It yields nothing for non-struct types.
+func jsonFields(t reflect.Type) func(yield func(string, reflect.Type) bool) {
+	return func(yield func(string, reflect.Type) bool) {
+		t = elem(t)
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for f := range t.Fields() {
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if !yield(name, f.Type) {
+				return
+			}
+		}
+	}
+}
+
+// elem strips pointer and slice layers from t.
+func elem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Parse parses a comma-separated list of field or parent.child names. Every
+// name must appear in allowed. Selecting a parent selects all of its
+// children. An empty selection yields a nil Mask.
+func Parse(raw string, allowed ...string) (Mask, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	m := Mask{}
+	for part := range strings.SplitSeq(raw, ",") {
+		field := strings.TrimSpace(part)
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty field in %q", ErrInvalidFields, raw)
+		}
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFields, field)
+		}
+		parent, child, nested := strings.Cut(field, ".")
+		if strings.Contains(child, ".") {
+			return nil, fmt.Errorf("%w: field %q is nested too deeply", ErrInvalidFields, field)
+		}
+		children, seen := m[parent]
+		switch {
+		case !nested:
+			m[parent] = nil
+		case seen && children == nil:
+			// The whole parent is already selected.
+		default:
+			m[parent] = append(children, child)
+		}
+	}
+	return m, nil
+}
+
+// Apply marshals v to JSON and returns the object with only the selected
+// fields, so the result serializes exactly as v would have. Fields that v
+// omits stay omitted. A nil Mask returns every field.
+func (m Mask) Apply(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %T: %w", v, err)
+	}
+	// UseNumber keeps numbers as their original text, so integers beyond
+	// 2^53 do not round through float64.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("decode %T as an object: %w", v, err)
+	}
+	if m == nil {
+		return obj, nil
+	}
+
+	out := make(map[string]any, len(m))
+	for field, children := range m {
+		value, ok := obj[field]
+		if !ok {
+			continue
+		}
+		if children != nil {
+			value = pick(value, children)
+		}
+		out[field] = value
+	}
+	return out, nil
+}
+
+// pick keeps only fields in a nested object, or in each object of a nested
+// array. Other values are returned unchanged.
+func pick(value any, fields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(fields))
+		for _, f := range fields {
+			if fv, ok := v[f]; ok {
+				out[f] = fv
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = pick(e, fields)
+		}
+		return out
+	default:
+		return value
+	}
+}
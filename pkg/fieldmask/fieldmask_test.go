@@ -0,0 +1,152 @@
+//go:build unit
+
+package fieldmask_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/fieldmask"
+)
+
+type owner struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type booking struct {
+	ID       string   `json:"id"`
+	Status   string   `json:"status"`
+	Note     string   `json:"note,omitempty"`
+	Owner    *owner   `json:"owner"`
+	Guests   []owner  `json:"guests"`
+	internal string   //nolint:unused // unexported fields are never selectable.
+	Skipped  string   `json:"-"`
+	Tags     []string `json:"tags"`
+}
+
+var sample = booking{
+	ID:     "b1",
+	Status: "confirmed",
+	Owner:  &owner{ID: "o1", Email: "o1@example.com"},
+	Guests: []owner{{ID: "g1", Email: "g1@example.com"}, {ID: "g2", Email: "g2@example.com"}},
+	Tags:   []string{"vip"},
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{
+		"guests", "guests.email", "guests.id",
+		"id", "note",
+		"owner", "owner.email", "owner.id",
+		"status", "tags",
+	}, fieldmask.Allowed[booking]())
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	allowed := fieldmask.Allowed[booking]()
+	tests := []struct {
+		name string
+		raw  string
+		want fieldmask.Mask
+	}{
+		{"empty", "", nil},
+		{"whitespace", "  ", nil},
+		{"top level", "id, status", fieldmask.Mask{"id": nil, "status": nil}},
+		{"nested", "owner.email,owner.id", fieldmask.Mask{"owner": {"email", "id"}}},
+		{"parent wins after child", "owner.email,owner", fieldmask.Mask{"owner": nil}},
+		{"parent wins before child", "owner,owner.email", fieldmask.Mask{"owner": nil}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := fieldmask.Parse(tc.raw, allowed...)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	t.Parallel()
+
+	allowed := append(fieldmask.Allowed[booking](), "owner.address.city")
+	for _, raw := range []string{"password", "id,,status", "Skipped", "internal", "owner.password", "owner.address.city"} {
+		_, err := fieldmask.Parse(raw, allowed...)
+		require.ErrorIs(t, err, fieldmask.ErrInvalidFields, raw)
+	}
+}
+
+func TestMask_Apply(t *testing.T) {
+	t.Parallel()
+
+	allowed := fieldmask.Allowed[booking]()
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{"top level", "id,status", `{"id":"b1","status":"confirmed"}`},
+		{"omitted field stays omitted", "id,note", `{"id":"b1"}`},
+		{"nested object", "id,owner.email", `{"id":"b1","owner":{"email":"o1@example.com"}}`},
+		{"nested array", "guests.id", `{"guests":[{"id":"g1"},{"id":"g2"}]}`},
+		{"whole parent", "owner", `{"owner":{"id":"o1","email":"o1@example.com"}}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := fieldmask.Parse(tc.fields, allowed...)
+			require.NoError(t, err)
+
+			got, err := m.Apply(sample)
+			require.NoError(t, err)
+			b, err := json.Marshal(got)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(b))
+		})
+	}
+}
+
+func TestMask_ApplyKeepsLargeNumbers(t *testing.T) {
+	t.Parallel()
+
+	type counter struct {
+		ID    int64   `json:"id"`
+		Total uint64  `json:"total"`
+		Ratio float64 `json:"ratio"`
+	}
+	m, err := fieldmask.Parse("id,total,ratio", fieldmask.Allowed[counter]()...)
+	require.NoError(t, err)
+
+	got, err := m.Apply(counter{ID: 1<<53 + 1, Total: 18446744073709551615, Ratio: 0.1})
+	require.NoError(t, err)
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":9007199254740993,"ratio":0.1,"total":18446744073709551615}`, string(b))
+}
+
+func TestMask_ApplyNilIsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	var m fieldmask.Mask
+	got, err := m.Apply(sample)
+	require.NoError(t, err)
+
+	filtered, err := json.Marshal(got)
+	require.NoError(t, err)
+	full, err := json.Marshal(sample)
+	require.NoError(t, err)
+	require.JSONEq(t, string(full), string(filtered))
+}
+
+func TestMask_ApplyRejectsNonObjects(t *testing.T) {
+	t.Parallel()
+
+	_, err := fieldmask.Mask{"id": nil}.Apply([]booking{sample})
+	require.Error(t, err)
+}
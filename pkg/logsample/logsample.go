@@ -0,0 +1,132 @@
+// Package logsample thins out repetitive log lines. For each key (typically a
+// route or an event name) and level it lets the first N lines of a window
+// through and then one in every M, so a hot path cannot flood the logs while
+// a burst of a new kind of line is still fully visible. Error and more severe
+// levels are never sampled.
+package logsample
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Rule is the sampling policy for one level: the first First lines of each
+// window pass, then every Thereafter-th line. Thereafter 0 drops the rest of
+// the window.
+type Rule struct {
+	First      uint64
+	Thereafter uint64
+}
+
+// Option configures a Sampler.
+type Option func(*Sampler)
+
+// WithClock overrides time.Now, for tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Sampler) { s.now = now }
+}
+
+// Sampler decides per key and level whether a line is logged. Levels without
+// a rule are never sampled. Counters are kept per distinct key until their
+// window ends, so keys must come from a bounded set such as route patterns.
+type Sampler struct {
+	window time.Duration
+	rules  map[zerolog.Level]Rule
+	now    func() time.Time
+
+	mu        sync.Mutex
+	counters  map[counterKey]*counter
+	lastSweep time.Time
+}
+
+type counterKey struct {
+	key   string
+	level zerolog.Level
+}
+
+type counter struct {
+	start time.Time
+	n     uint64
+}
+
+// New returns a Sampler applying rules within windows of the given length.
+// Rules for zerolog.ErrorLevel and above are ignored.
+func New(window time.Duration, rules map[zerolog.Level]Rule, opts ...Option) *Sampler {
+	s := &Sampler{
+		window:   window,
+		rules:    make(map[zerolog.Level]Rule, len(rules)),
+		now:      time.Now,
+		counters: make(map[counterKey]*counter),
+	}
+	for lvl, r := range rules {
+		if lvl < zerolog.ErrorLevel {
+			s.rules[lvl] = r
+		}
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Allow reports whether a line with key at level should be logged. A nil
+// Sampler allows everything.
+func (s *Sampler) Allow(key string, level zerolog.Level) bool {
+	if s == nil {
+		return true
+	}
+	rule, ok := s.rules[level]
+	if !ok {
+		return true
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	ck := counterKey{key: key, level: level}
+	c, ok := s.counters[ck]
+	if !ok || now.Sub(c.start) >= s.window {
+		c = &counter{start: now}
+		s.counters[ck] = c
+	}
+	c.n++
+
+	if c.n <= rule.First {
+		return true
+	}
+	return rule.Thereafter > 0 && (c.n-rule.First)%rule.Thereafter == 0
+}
+
+// sweep drops counters whose window has ended, at most once per window, so
+// keys that stop appearing do not stay in memory. s.mu must be held.
+func (s *Sampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.window {
+		return
+	}
+	s.lastSweep = now
+	for ck, c := range s.counters {
+		if now.Sub(c.start) >= s.window {
+			delete(s.counters, ck)
+		}
+	}
+}
+
+// Logger returns a copy of l whose lines are sampled under key.
+func (s *Sampler) Logger(l *zerolog.Logger, key string) *zerolog.Logger {
+	sampled := l.Sample(keySampler{s: s, key: key})
+	return &sampled
+}
+
+// keySampler adapts a Sampler to zerolog.Sampler for one key.
+type keySampler struct {
+	s   *Sampler
+	key string
+}
+
+func (k keySampler) Sample(level zerolog.Level) bool {
+	return k.s.Allow(k.key, level)
+}
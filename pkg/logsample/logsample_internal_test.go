@@ -0,0 +1,30 @@
+//go:build unit
+
+package logsample
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampler_DropsExpiredCounters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	s := New(time.Second, map[zerolog.Level]Rule{
+		zerolog.InfoLevel: {First: 1},
+	}, WithClock(func() time.Time { return now }))
+
+	for i := range 100 {
+		s.Allow("key-"+strconv.Itoa(i), zerolog.InfoLevel)
+	}
+	assert.Len(t, s.counters, 100)
+
+	now = now.Add(time.Second)
+	s.Allow("fresh", zerolog.InfoLevel)
+	assert.Len(t, s.counters, 1)
+}
@@ -0,0 +1,102 @@
+//go:build unit
+
+package logsample_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zercle/zercle-go-template/pkg/logsample"
+)
+
+func TestSampler_FirstThenEveryNth(t *testing.T) {
+	t.Parallel()
+
+	s := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel: {First: 3, Thereafter: 5},
+	})
+
+	var allowed []int
+	for i := 1; i <= 20; i++ {
+		if s.Allow("list", zerolog.InfoLevel) {
+			allowed = append(allowed, i)
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 8, 13, 18}, allowed)
+}
+
+func TestSampler_KeysAndLevelsAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	s := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel:  {First: 1},
+		zerolog.DebugLevel: {First: 1},
+	})
+
+	assert.True(t, s.Allow("a", zerolog.InfoLevel))
+	assert.False(t, s.Allow("a", zerolog.InfoLevel))
+	assert.True(t, s.Allow("b", zerolog.InfoLevel))
+	assert.True(t, s.Allow("a", zerolog.DebugLevel))
+	assert.True(t, s.Allow("a", zerolog.WarnLevel), "levels without a rule are not sampled")
+}
+
+func TestSampler_ErrorsNeverSampled(t *testing.T) {
+	t.Parallel()
+
+	s := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.ErrorLevel: {First: 0},
+		zerolog.InfoLevel:  {First: 1},
+	})
+
+	for range 100 {
+		assert.True(t, s.Allow("k", zerolog.ErrorLevel))
+		assert.True(t, s.Allow("k", zerolog.FatalLevel))
+	}
+}
+
+func TestSampler_WindowResets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	s := logsample.New(time.Second, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel: {First: 1},
+	}, logsample.WithClock(func() time.Time { return now }))
+
+	assert.True(t, s.Allow("k", zerolog.InfoLevel))
+	assert.False(t, s.Allow("k", zerolog.InfoLevel))
+
+	now = now.Add(time.Second)
+	assert.True(t, s.Allow("k", zerolog.InfoLevel))
+}
+
+func TestSampler_Logger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+	s := logsample.New(time.Minute, map[zerolog.Level]logsample.Rule{
+		zerolog.InfoLevel: {First: 2},
+	})
+	l := s.Logger(&base, "list items")
+
+	for range 10 {
+		l.Info().Msg("listed")
+		l.Error().Msg("failed")
+	}
+
+	assert.Equal(t, 2, strings.Count(buf.String(), `"listed"`))
+	assert.Equal(t, 10, strings.Count(buf.String(), `"failed"`))
+}
+
+func TestSampler_NilAllowsAll(t *testing.T) {
+	t.Parallel()
+
+	var s *logsample.Sampler
+	assert.True(t, s.Allow("k", zerolog.InfoLevel))
+}
@@ -0,0 +1,230 @@
+// Package readthrough provides a small in-memory read-through cache that
+// collapses concurrent loads of the same key into one call (singleflight)
+// and keeps the result for a short TTL.
+//
+// It is meant for hot, identical-for-everyone reads where a few seconds of
+// staleness is acceptable. Writers call Invalidate so their own changes are
+// visible immediately. With WithStaleWhileRevalidate, an expired entry keeps
+// being served for a grace period while a single background load refreshes
+// it, so readers never wait on a reload of a key that is in use.
+package readthrough
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultMaxEntries bounds the number of cached keys.
+const defaultMaxEntries = 1024
+
+// Option configures a Cache.
+type Option func(*options)
+
+type options struct {
+	now        func() time.Time
+	meter      metric.Meter
+	maxEntries int
+	stale      time.Duration
+}
+
+// WithClock overrides time.Now, for tests.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) { o.now = now }
+}
+
+// WithMeter records hit, miss, and collapsed-call counters on meter.
+func WithMeter(meter metric.Meter) Option {
+	return func(o *options) { o.meter = meter }
+}
+
+// WithMaxEntries bounds the number of cached keys; new keys are not cached
+// while the cache is full of unexpired entries.
+func WithMaxEntries(n int) Option {
+	return func(o *options) { o.maxEntries = n }
+}
+
+// WithStaleWhileRevalidate serves an entry for up to d after it expires. The
+// first read of an expired entry starts a background load, shared with any
+// concurrent loads of the key, and gets the stale value; later reads see the
+// new value once it is stored. A failed refresh leaves the stale entry in
+// place until the grace period ends.
+func WithStaleWhileRevalidate(d time.Duration) Option {
+	return func(o *options) { o.stale = d }
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is a read-through cache of V values keyed by string.
+type Cache[V any] struct {
+	name       string
+	ttl        time.Duration
+	stale      time.Duration
+	now        func() time.Time
+	maxEntries int
+	group      singleflight.Group
+
+	mu         sync.Mutex
+	entries    map[string]entry[V]
+	generation uint64
+
+	attrs     metric.MeasurementOption
+	hits      metric.Int64Counter
+	misses    metric.Int64Counter
+	collapsed metric.Int64Counter
+	staleHits metric.Int64Counter
+}
+
+// New returns a Cache whose entries live for ttl. name labels the metrics.
+func New[V any](name string, ttl time.Duration, opts ...Option) (*Cache[V], error) {
+	o := options{now: time.Now, meter: noop.NewMeterProvider().Meter(""), maxEntries: defaultMaxEntries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Cache[V]{
+		name:       name,
+		ttl:        ttl,
+		stale:      max(o.stale, 0),
+		now:        o.now,
+		maxEntries: o.maxEntries,
+		entries:    make(map[string]entry[V]),
+		attrs:      metric.WithAttributes(attribute.String("cache", name)),
+	}
+
+	var err error
+	if c.hits, err = o.meter.Int64Counter("readthrough.hits", metric.WithDescription("Reads served from the cache.")); err != nil {
+		return nil, fmt.Errorf("create hits counter: %w", err)
+	}
+	if c.misses, err = o.meter.Int64Counter("readthrough.misses", metric.WithDescription("Reads that called the loader.")); err != nil {
+		return nil, fmt.Errorf("create misses counter: %w", err)
+	}
+	if c.collapsed, err = o.meter.Int64Counter("readthrough.collapsed", metric.WithDescription("Reads that shared an in-flight load.")); err != nil {
+		return nil, fmt.Errorf("create collapsed counter: %w", err)
+	}
+
+	if c.staleHits, err = o.meter.Int64Counter("readthrough.stale", metric.WithDescription("Reads served an expired entry while it refreshed.")); err != nil {
+		return nil, fmt.Errorf("create stale counter: %w", err)
+	}
+	return c, nil
+}
+
+// Get returns the cached value for key or loads it. Concurrent Gets for the
+// same missing key share a single load call. The load runs detached from the
+// caller's cancellation so one impatient caller cannot fail the others; it
+// keeps ctx values such as the trace span. Errors are never cached. A Get
+// issued after Invalidate never joins a load that started before it.
+func (c *Cache[V]) Get(ctx context.Context, key string, load func(context.Context) (V, error)) (V, error) {
+	v, found, fresh, generation := c.lookup(key)
+	if found && fresh {
+		c.hits.Add(ctx, 1, c.attrs)
+		return v, nil
+	}
+	flight := flightKey(generation, key)
+	if found {
+		c.staleHits.Add(ctx, 1, c.attrs)
+		c.group.DoChan(flight, c.loader(ctx, key, generation, load))
+		return v, nil
+	}
+
+	leader := false
+	loadAndStore := c.loader(ctx, key, generation, load)
+	res, err, _ := c.group.Do(flight, func() (any, error) {
+		leader = true
+		return loadAndStore()
+	})
+	if leader {
+		c.misses.Add(ctx, 1, c.attrs)
+	} else {
+		c.collapsed.Add(ctx, 1, c.attrs)
+	}
+
+	v, _ = res.(V)
+	if err != nil {
+		return v, fmt.Errorf("load %s %q: %w", c.name, key, err)
+	}
+	return v, nil
+}
+
+// flightKey scopes singleflight calls to a cache generation, so loads
+// started before Invalidate are not shared with reads issued after it.
+func flightKey(generation uint64, key string) string {
+	return fmt.Sprintf("%d\x00%s", generation, key)
+}
+
+// loader returns the singleflight function that loads key, detached from
+// ctx's cancellation, and caches the result unless Invalidate ran since
+// generation was read.
+func (c *Cache[V]) loader(ctx context.Context, key string, generation uint64, load func(context.Context) (V, error)) func() (any, error) {
+	return func() (any, error) {
+		v, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return v, err
+		}
+		c.store(key, v, generation)
+		return v, nil
+	}
+}
+
+// Invalidate drops every entry. Loads already in flight when Invalidate is
+// called still return to the callers that joined them but are not cached,
+// and later Gets start a fresh load.
+func (c *Cache[V]) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// lookup reports whether key has a usable entry and whether it is still
+// fresh rather than within the stale grace period, along with the cache
+// generation the answer belongs to.
+func (c *Cache[V]) lookup(key string) (v V, found, fresh bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generation = c.generation
+	e, ok := c.entries[key]
+	if !ok {
+		return v, false, false, generation
+	}
+	now := c.now()
+	if now.Before(e.expiresAt) {
+		return e.value, true, true, generation
+	}
+	if now.Before(e.expiresAt.Add(c.stale)) {
+		return e.value, true, false, generation
+	}
+	return v, false, false, generation
+}
+
+func (c *Cache[V]) store(key string, v V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	now := c.now()
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt.Add(c.stale)) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+	c.entries[key] = entry[V]{value: v, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,373 @@
+//go:build unit
+
+package readthrough_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/readthrough"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func newCache(t *testing.T, clock *fakeClock, opts ...readthrough.Option) *readthrough.Cache[int] {
+	t.Helper()
+
+	opts = append([]readthrough.Option{readthrough.WithClock(clock.Now)}, opts...)
+	cache, err := readthrough.New[int]("test", time.Second, opts...)
+	require.NoError(t, err)
+	return cache
+}
+
+func counting(calls *atomic.Int32, value int) func(context.Context) (int, error) {
+	return func(context.Context) (int, error) {
+		calls.Add(1)
+		return value, nil
+	}
+}
+
+func TestCache_ServesFromCacheUntilExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock)
+	var calls atomic.Int32
+
+	for range 3 {
+		v, err := cache.Get(ctx, "k", counting(&calls, 7))
+		require.NoError(t, err)
+		require.Equal(t, 7, v)
+	}
+	require.EqualValues(t, 1, calls.Load())
+
+	clock.Advance(time.Second)
+	_, err := cache.Get(ctx, "k", counting(&calls, 7))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestCache_KeysAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+	var calls atomic.Int32
+
+	a, err := cache.Get(ctx, "a", counting(&calls, 1))
+	require.NoError(t, err)
+	b, err := cache.Get(ctx, "b", counting(&calls, 2))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, a)
+	require.Equal(t, 2, b)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestCache_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+	boom := errors.New("boom")
+
+	_, err := cache.Get(ctx, "k", func(context.Context) (int, error) { return 0, boom })
+	require.ErrorIs(t, err, boom)
+
+	var calls atomic.Int32
+	v, err := cache.Get(ctx, "k", counting(&calls, 3))
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+	require.EqualValues(t, 1, calls.Load())
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+	var calls atomic.Int32
+
+	_, err := cache.Get(ctx, "k", counting(&calls, 1))
+	require.NoError(t, err)
+	cache.Invalidate()
+	v, err := cache.Get(ctx, "k", counting(&calls, 2))
+	require.NoError(t, err)
+
+	require.Equal(t, 2, v)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestCache_InvalidateDuringLoadSkipsStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+	var calls atomic.Int32
+
+	v, err := cache.Get(ctx, "k", func(context.Context) (int, error) {
+		calls.Add(1)
+		cache.Invalidate()
+		return 1, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	v, err = cache.Get(ctx, "k", counting(&calls, 2))
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestCache_GetAfterInvalidateDoesNotJoinOldLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	oldDone := make(chan int)
+	go func() {
+		v, _ := cache.Get(ctx, "k", func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		oldDone <- v
+	}()
+	<-started
+
+	cache.Invalidate()
+	var calls atomic.Int32
+	v, err := cache.Get(ctx, "k", counting(&calls, 2))
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+	require.EqualValues(t, 1, calls.Load())
+
+	close(release)
+	require.Equal(t, 1, <-oldDone)
+
+	// The load from before Invalidate must not overwrite the fresh entry.
+	v, err = cache.Get(ctx, "k", counting(&calls, 3))
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+}
+
+func TestCache_CollapsesConcurrentLoads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+
+	const callers = 100
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	results := make([]int, callers)
+	for i := range callers {
+		go func() {
+			defer done.Done()
+			started.Done()
+			v, err := cache.Get(ctx, "k", load)
+			if err == nil {
+				results[i] = v
+			}
+		}()
+	}
+	started.Wait()
+	// Give the goroutines a moment to join the in-flight load before it
+	// finishes; stragglers are served from the cache either way.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	require.EqualValues(t, 1, calls.Load())
+	for _, v := range results {
+		require.Equal(t, 42, v)
+	}
+}
+
+func TestCache_LoadSurvivesCallerCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cache := newCache(t, &fakeClock{now: time.Unix(0, 0)})
+
+	v, err := cache.Get(ctx, "k", func(ctx context.Context) (int, error) {
+		return 5, ctx.Err()
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, v)
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock, readthrough.WithMaxEntries(1))
+	var calls atomic.Int32
+
+	_, err := cache.Get(ctx, "a", counting(&calls, 1))
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, "b", counting(&calls, 2))
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, "b", counting(&calls, 2))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, calls.Load(), "b is not cached while the cache is full")
+
+	clock.Advance(time.Second)
+	_, err = cache.Get(ctx, "b", counting(&calls, 2))
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, "b", counting(&calls, 2))
+	require.NoError(t, err)
+	require.EqualValues(t, 4, calls.Load(), "expired entries make room")
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock, readthrough.WithStaleWhileRevalidate(time.Second))
+
+	v, err := cache.Get(ctx, "k", func(context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	// Expired but within the grace period: every reader gets the stale
+	// value at once and a single refresh runs in the background.
+	clock.Advance(1500 * time.Millisecond)
+	release := make(chan struct{})
+	var calls atomic.Int32
+	refresh := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 2, nil
+	}
+	for range 5 {
+		v, err := cache.Get(ctx, "k", refresh)
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+	}
+	close(release)
+	require.Eventually(t, func() bool {
+		v, err := cache.Get(ctx, "k", refresh)
+		return err == nil && v == 2
+	}, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, calls.Load())
+
+	// Past the grace period the entry is gone and readers wait for a load.
+	clock.Advance(2 * time.Second)
+	v, err = cache.Get(ctx, "k", func(context.Context) (int, error) { return 3, nil })
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+}
+
+func TestCache_StaleKeptWhenRefreshFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock, readthrough.WithStaleWhileRevalidate(time.Second))
+
+	_, err := cache.Get(ctx, "k", func(context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+
+	clock.Advance(1500 * time.Millisecond)
+	var calls atomic.Int32
+	failing := func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("boom")
+	}
+	v, err := cache.Get(ctx, "k", failing)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	v, err = cache.Get(ctx, "k", failing)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+}
+
+func TestCache_GetAfterInvalidateDoesNotJoinStaleRefresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock, readthrough.WithStaleWhileRevalidate(time.Minute))
+
+	_, err := cache.Get(ctx, "k", func(context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+	clock.Advance(2 * time.Second)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	v, err := cache.Get(ctx, "k", func(context.Context) (int, error) {
+		close(started)
+		<-release
+		return 2, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+	<-started
+
+	cache.Invalidate()
+	var calls atomic.Int32
+	v, err = cache.Get(ctx, "k", counting(&calls, 3))
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+	require.EqualValues(t, 1, calls.Load())
+}
+
+func TestCache_InvalidateDropsStaleEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := newCache(t, clock, readthrough.WithStaleWhileRevalidate(time.Minute))
+	var calls atomic.Int32
+
+	_, err := cache.Get(ctx, "k", counting(&calls, 1))
+	require.NoError(t, err)
+	clock.Advance(2 * time.Second)
+	cache.Invalidate()
+
+	v, err := cache.Get(ctx, "k", counting(&calls, 2))
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+	require.EqualValues(t, 2, calls.Load())
+}
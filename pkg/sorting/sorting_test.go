@@ -0,0 +1,132 @@
+//go:build unit
+
+package sorting_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/sorting"
+)
+
+var allowed = []string{"created_at", "name"}
+
+var columns = sorting.Columns{"created_at": "created_at", "name": "name"}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		raw  string
+		want []sorting.Key
+	}{
+		{"empty", "", nil},
+		{"whitespace", "   ", nil},
+		{"default direction", "name", []sorting.Key{{Field: "name", Direction: sorting.Asc}}},
+		{"explicit desc", "name:desc", []sorting.Key{{Field: "name", Direction: sorting.Desc}}},
+		{"case-insensitive direction", "name:DESC", []sorting.Key{{Field: "name", Direction: sorting.Desc}}},
+		{
+			"multiple keys",
+			"name:asc, created_at:desc",
+			[]sorting.Key{{Field: "name", Direction: sorting.Asc}, {Field: "created_at", Direction: sorting.Desc}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := sorting.Parse(tc.raw, allowed...)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown field", "price:asc"},
+		{"unknown direction", "name:up"},
+		{"duplicate field", "name:asc,name:desc"},
+		{"empty key", "name,,created_at"},
+		{"sql injection in field", "name; DROP TABLE items--"},
+		{"sql injection in direction", "name:asc; DROP TABLE items--"},
+		{"quoted identifier", `"name"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := sorting.Parse(tc.raw, allowed...)
+			require.ErrorIs(t, err, sorting.ErrInvalidSort)
+			require.Nil(t, keys)
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		keys       []sorting.Key
+		tieBreaker string
+		want       string
+	}{
+		{
+			"single key with tie-breaker",
+			[]sorting.Key{{Field: "name", Direction: sorting.Asc}},
+			"id",
+			"name ASC, id ASC",
+		},
+		{
+			"tie-breaker follows last direction",
+			[]sorting.Key{{Field: "name", Direction: sorting.Asc}, {Field: "created_at", Direction: sorting.Desc}},
+			"id",
+			"name ASC, created_at DESC, id DESC",
+		},
+		{
+			"no tie-breaker",
+			[]sorting.Key{{Field: "created_at", Direction: sorting.Desc}},
+			"",
+			"created_at DESC",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := sorting.OrderBy(tc.keys, columns, tc.tieBreaker)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOrderBy_RejectsUnvalidatedKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		keys []sorting.Key
+	}{
+		{"no keys", nil},
+		{"unknown field", []sorting.Key{{Field: "name; DROP TABLE items", Direction: sorting.Asc}}},
+		{"unknown direction", []sorting.Key{{Field: "name", Direction: "asc; DROP TABLE items"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := sorting.OrderBy(tc.keys, columns, "id")
+			require.ErrorIs(t, err, sorting.ErrInvalidSort)
+			require.Empty(t, got)
+		})
+	}
+}
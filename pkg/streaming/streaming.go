@@ -0,0 +1,162 @@
+// Package streaming tracks long-lived streaming responses (SSE, chunked
+// downloads, long polls) so graceful shutdown can end them promptly.
+//
+// http.Server.Shutdown waits for every in-flight request to finish, and a
+// stream never finishes on its own, so without help shutdown would always run
+// into its timeout. A streaming handler instead registers itself:
+//
+//	ctx, id := registry.RegisterStream(c.Request().Context())
+//	defer registry.UnregisterStream(id)
+//	for {
+//		select {
+//		case <-ctx.Done():
+//			if streaming.IsShutdown(ctx) {
+//				_ = streaming.WriteEvent(w, streaming.ShutdownEvent, "{}")
+//			}
+//			return nil
+//		case msg := <-updates:
+//			...
+//		}
+//	}
+//
+// During shutdown, Registry.Shutdown cancels every stream context and waits
+// for the handlers to unregister before the HTTP server drains the remaining
+// ordinary requests.
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ShutdownEvent is the SSE event name handlers send before closing a stream
+// because the server is shutting down.
+const ShutdownEvent = "server_shutdown"
+
+// ErrServerShutdown is the cancellation cause of stream contexts ended by
+// Registry.Shutdown.
+var ErrServerShutdown = errors.New("server shutdown")
+
+// ID identifies a registered stream. The zero ID is never assigned.
+type ID uint64
+
+// Registry tracks the active streams. The zero value is not usable; call
+// NewRegistry.
+type Registry struct {
+	mu       sync.Mutex
+	next     ID
+	streams  map[ID]context.CancelCauseFunc
+	closing  bool
+	drained  chan struct{}
+	signaled bool
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		streams: make(map[ID]context.CancelCauseFunc),
+		drained: make(chan struct{}),
+	}
+}
+
+// RegisterStream returns a context derived from ctx that is canceled with
+// ErrServerShutdown when Shutdown is called, plus the ID to pass to
+// UnregisterStream once the handler returns. After Shutdown has started, the
+// returned context is already canceled.
+func (r *Registry) RegisterStream(ctx context.Context) (context.Context, ID) {
+	streamCtx, cancel := context.WithCancelCause(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closing {
+		cancel(ErrServerShutdown)
+		return streamCtx, 0
+	}
+
+	r.next++
+	r.streams[r.next] = cancel
+	return streamCtx, r.next
+}
+
+// UnregisterStream releases the stream's context. It is safe to call more
+// than once and with the zero ID.
+func (r *Registry) UnregisterStream(id ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancel, ok := r.streams[id]
+	if !ok {
+		return
+	}
+	cancel(context.Canceled)
+	delete(r.streams, id)
+	r.signalDrainedLocked()
+}
+
+// Active returns the number of registered streams.
+func (r *Registry) Active() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.streams)
+}
+
+// Shutdown cancels every registered stream with ErrServerShutdown, rejects
+// new registrations, and waits until all streams have unregistered or ctx is
+// done.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closing = true
+	for _, cancel := range r.streams {
+		cancel(ErrServerShutdown)
+	}
+	r.signalDrainedLocked()
+	r.mu.Unlock()
+
+	select {
+	case <-r.drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d streams still open: %w", r.Active(), ctx.Err())
+	}
+}
+
+// signalDrainedLocked closes drained once shutdown has begun and the last
+// stream is gone. r.mu must be held.
+func (r *Registry) signalDrainedLocked() {
+	if r.closing && len(r.streams) == 0 && !r.signaled {
+		r.signaled = true
+		close(r.drained)
+	}
+}
+
+// IsShutdown reports whether ctx was canceled by Registry.Shutdown, as
+// opposed to the client going away.
+func IsShutdown(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrServerShutdown)
+}
+
+// WriteEvent writes a single server-sent event and flushes it to the client.
+// Multi-line data is split across data: fields as the SSE format requires.
+func WriteEvent(w http.ResponseWriter, event, data string) error {
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	for line := range strings.SplitSeq(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	if _, err := w.Write([]byte(b.String())); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+	if err := http.NewResponseController(w).Flush(); err != nil {
+		return fmt.Errorf("flush event: %w", err)
+	}
+	return nil
+}
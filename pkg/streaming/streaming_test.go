@@ -0,0 +1,104 @@
+//go:build unit
+
+package streaming_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zercle/zercle-go-template/pkg/streaming"
+)
+
+func TestRegistry_ShutdownCancelsStreams(t *testing.T) {
+	t.Parallel()
+
+	r := streaming.NewRegistry()
+	ctx, id := r.RegisterStream(context.Background())
+	require.Equal(t, 1, r.Active())
+
+	go func() {
+		<-ctx.Done()
+		r.UnregisterStream(id)
+	}()
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.True(t, streaming.IsShutdown(ctx))
+	require.Zero(t, r.Active())
+}
+
+func TestRegistry_ShutdownWithoutStreams(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, streaming.NewRegistry().Shutdown(context.Background()))
+}
+
+func TestRegistry_ShutdownTimesOut(t *testing.T) {
+	t.Parallel()
+
+	r := streaming.NewRegistry()
+	_, _ = r.RegisterStream(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := r.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "1 streams still open")
+}
+
+func TestRegistry_RejectsAfterShutdown(t *testing.T) {
+	t.Parallel()
+
+	r := streaming.NewRegistry()
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	ctx, id := r.RegisterStream(context.Background())
+	require.Error(t, ctx.Err())
+	require.True(t, streaming.IsShutdown(ctx))
+	require.Zero(t, id)
+	require.Zero(t, r.Active())
+	r.UnregisterStream(id)
+}
+
+func TestRegistry_UnregisterReleasesContext(t *testing.T) {
+	t.Parallel()
+
+	r := streaming.NewRegistry()
+	ctx, id := r.RegisterStream(context.Background())
+
+	r.UnregisterStream(id)
+	r.UnregisterStream(id)
+
+	require.Error(t, ctx.Err())
+	require.False(t, streaming.IsShutdown(ctx))
+	require.Zero(t, r.Active())
+}
+
+func TestIsShutdown_ClientGone(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithCancel(context.Background())
+	r := streaming.NewRegistry()
+	ctx, id := r.RegisterStream(parent)
+	defer r.UnregisterStream(id)
+
+	cancel()
+
+	require.Error(t, ctx.Err())
+	require.False(t, streaming.IsShutdown(ctx))
+}
+
+func TestWriteEvent(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, streaming.WriteEvent(rec, streaming.ShutdownEvent, "line one\nline two"))
+
+	require.Equal(t, "event: server_shutdown\ndata: line one\ndata: line two\n\n", rec.Body.String())
+	require.True(t, rec.Flushed)
+}
@@ -1,6 +1,12 @@
+// Package uuidgen generates the IDs of new rows. IDs are UUIDv7, so they sort
+// by creation time and keep primary-key inserts local in the index. Rows
+// created before the switch may carry v4 IDs; those still parse and compare
+// as ordinary UUIDs.
 package uuidgen
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
@@ -24,3 +30,13 @@ func NewString() string {
 	log.Warn().Err(err).Msg("Failed to generate UUIDv7, falling back to uuid.NewString()")
 	return uuid.NewString()
 }
+
+// TimeFromID returns the creation time, to the millisecond, embedded in a
+// UUIDv7. It reports false for other versions, which carry no usable time.
+func TimeFromID(id uuid.UUID) (time.Time, bool) {
+	if id.Version() != 7 {
+		return time.Time{}, false
+	}
+	sec, nsec := id.Time().UnixTime()
+	return time.Unix(sec, nsec).UTC(), true
+}
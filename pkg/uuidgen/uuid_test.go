@@ -1,7 +1,14 @@
 package uuidgen
 
 import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -30,3 +37,71 @@ func TestNew_ReturnsV7(t *testing.T) {
 		t.Errorf("expected UUID version 7, got %d", version)
 	}
 }
+
+func TestNew_IncreasesMonotonically(t *testing.T) {
+	t.Parallel()
+	prev := New()
+	for range 1000 {
+		id := New()
+		if bytes.Compare(id[:], prev[:]) <= 0 {
+			t.Fatalf("expected %s to sort after %s", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestTimeFromID(t *testing.T) {
+	t.Parallel()
+	before := time.Now().Truncate(time.Millisecond)
+	id := New()
+	after := time.Now()
+
+	got, ok := TimeFromID(id)
+	if !ok {
+		t.Fatal("expected a time from a UUIDv7")
+	}
+	if got.Before(before) || got.After(after) {
+		t.Errorf("expected a time between %v and %v, got %v", before, after, got)
+	}
+
+	if _, ok := TimeFromID(uuid.New()); ok {
+		t.Error("expected no time from a UUIDv4")
+	}
+}
+
+// idCall matches calls that mint an ID without going through this package.
+var idCall = regexp.MustCompile(`\buuid\.(New|NewString|NewRandom|NewV7)\(`)
+
+// TestNoDirectIDGeneration keeps every non-test ID in the module on UUIDv7 by
+// requiring generation to go through this package.
+func TestNoDirectIDGeneration(t *testing.T) {
+	t.Parallel()
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "vendor", "uuidgen":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if loc := idCall.FindIndex(src); loc != nil {
+			line := bytes.Count(src[:loc[0]], []byte("\n")) + 1
+			t.Errorf("%s:%d: generate IDs with uuidgen.New instead of %s", path, line, src[loc[0]:loc[1]])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/do/v2"
 	"github.com/stretchr/testify/require"
+	"github.com/valkey-io/valkey-go"
 
 	"github.com/zercle/zercle-go-template/internal/app"
 	"github.com/zercle/zercle-go-template/internal/config"
@@ -96,6 +98,70 @@ func TestServer_EndToEnd(t *testing.T) {
 	_ = resp.Body.Close()
 }
 
+// TestServer_ValkeyDisabled boots the full container with VALKEY_ENABLED=false
+// and an unreachable Valkey address, and checks that the server still comes
+// up: probes pass, the example routes work, and no Valkey client is wired.
+func TestServer_ValkeyDisabled(t *testing.T) {
+	t.Setenv("VALKEY_ENABLED", "false")
+	t.Setenv("VALKEY_HOST", "192.0.2.1") // TEST-NET-1, never dialled when disabled
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	require.False(t, cfg.Valkey.Enabled)
+
+	if !tcpReachable(fmt.Sprintf("%s:%d", cfg.DB.Host, cfg.DB.Port), 2*time.Second) {
+		t.Skip("requires: docker compose up postgres")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	application, injector, err := app.Build(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := injector.Shutdown(); err != nil {
+			t.Logf("injector shutdown error: %v", err)
+		}
+	})
+
+	_, err = do.Invoke[valkey.Client](injector)
+	require.ErrorIs(t, err, do.ErrServiceNotFound, "a disabled Valkey must not be wired")
+
+	server := httptest.NewServer(application.Echo())
+	t.Cleanup(server.Close)
+
+	go func() {
+		if err := application.Run(ctx); err != nil {
+			t.Logf("application run stopped: %v", err)
+		}
+	}()
+
+	select {
+	case <-application.HasHTTPStarted():
+	case <-time.After(2 * time.Second):
+		t.Fatal("application HTTP server never started")
+	}
+
+	client := server.Client()
+	get := func(path string) int {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, get("/healthz"))
+	require.Eventually(t, func() bool { return get("/readyz") == http.StatusOK },
+		5*time.Second, 250*time.Millisecond, "readiness probe never passed without Valkey")
+
+	resp, err := client.Post(server.URL+"/api/v1/items", "application/json", strings.NewReader(`{"name":"no-valkey"}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, get("/api/v1/items"))
+}
+
 // infraReachable returns true when both postgres and valkey respond to TCP
 // probes. It is used to decide whether to skip the e2e suite because the
 // required backing services are not running.